@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"io"
 
-	"github.com/go-playground/errors/v5"
-	nestedpackagee "github.com/go-playground/errors/v5/_examples/basic/nestedpackage"
+	"github.com/go-playground/errors/v6"
+	nestedpackagee "github.com/go-playground/errors/v6/_examples/basic/nestedpackage"
 )
 
 func main() {
@@ -3,7 +3,7 @@ package nestedpackagee
 import (
 	"io"
 
-	"github.com/go-playground/errors/v5"
+	"github.com/go-playground/errors/v6"
 )
 
 func GetUser(userID string) error {
@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"net"
 
-	"github.com/go-playground/errors/v5"
+	"github.com/go-playground/errors/v6"
 	// init function handles registration automatically
-	_ "github.com/go-playground/errors/v5/helpers/neterrors"
+	_ "github.com/go-playground/errors/v6/helpers/neterrors"
 )
 
 func main() {
@@ -4,7 +4,7 @@ import (
 	"fmt"
 	"net"
 
-	"github.com/go-playground/errors/v5"
+	"github.com/go-playground/errors/v6"
 )
 
 func main() {
@@ -0,0 +1,122 @@
+package errors
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+// tagValueAppenders contains the registered Tag value appenders by type.
+var tagValueAppenders map[reflect.Type]func([]byte, any) []byte
+
+// RegisterTagValueAppender registers a function used to append Tag values of type T by all the formatters, allowing
+// efficient and consistent encoding of types not handled by AppendTagValue; it takes precedence over the built-in
+// encoding of the type.
+// NOTE: this is not concurrency safe and should be called during initialization.
+func RegisterTagValueAppender[T any](fn func(b []byte, v T) []byte) {
+	if tagValueAppenders == nil {
+		tagValueAppenders = make(map[reflect.Type]func([]byte, any) []byte)
+	}
+	tagValueAppenders[reflect.TypeOf((*T)(nil)).Elem()] = func(b []byte, v any) []byte {
+		return fn(b, v.(T))
+	}
+}
+
+// AppendTagValue appends the Tag value using strconv where possible to avoid allocations.
+//
+// time.Time values are appended in RFC3339Nano format, []byte as a string and fmt.Stringer and error types using their
+// String and Error methods respectively. Other types can be registered using RegisterTagValueAppender.
+//
+// NOTE: use Tag.RedactedValue to obtain the value to ensure sensitive values are redacted.
+func AppendTagValue(b []byte, v any) []byte {
+	if len(tagValueAppenders) > 0 {
+		if fn, ok := tagValueAppenders[reflect.TypeOf(v)]; ok {
+			return fn(b, v)
+		}
+	}
+	switch t := v.(type) {
+	case string:
+		b = append(b, t...)
+	case int:
+		b = strconv.AppendInt(b, int64(t), 10)
+	case int8:
+		b = strconv.AppendInt(b, int64(t), 10)
+	case int16:
+		b = strconv.AppendInt(b, int64(t), 10)
+	case int32:
+		b = strconv.AppendInt(b, int64(t), 10)
+	case int64:
+		b = strconv.AppendInt(b, t, 10)
+	case uint:
+		b = strconv.AppendUint(b, uint64(t), 10)
+	case uint8:
+		b = strconv.AppendUint(b, uint64(t), 10)
+	case uint16:
+		b = strconv.AppendUint(b, uint64(t), 10)
+	case uint32:
+		b = strconv.AppendUint(b, uint64(t), 10)
+	case uint64:
+		b = strconv.AppendUint(b, t, 10)
+	case float32:
+		b = strconv.AppendFloat(b, float64(t), 'g', -1, 32)
+	case float64:
+		b = strconv.AppendFloat(b, t, 'g', -1, 64)
+	case bool:
+		b = strconv.AppendBool(b, t)
+	case time.Time:
+		b = t.AppendFormat(b, time.RFC3339Nano)
+	case time.Duration:
+		b = append(b, t.String()...)
+	case []byte:
+		b = append(b, t...)
+	case error:
+		b = appendMethod(b, v, t.Error)
+	case fmt.Stringer:
+		b = appendMethod(b, v, t.String)
+	default:
+		b = append(b, fmt.Sprintf("%v", v)...)
+	}
+	return b
+}
+
+// appendMethod appends the result of the String or Error method of v, printing <nil> when the method panics on a nil
+// pointer receiver in the same way as fmt.
+func appendMethod(b []byte, v any, method func() string) (result []byte) {
+	defer func() {
+		if r := recover(); r != nil {
+			if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+				result = append(b, "<nil>"...)
+				return
+			}
+			panic(r)
+		}
+	}()
+	return append(b, method()...)
+}
+
+// AppendTypes appends the types separated by a comma, as formatted by Error.
+func AppendTypes(b []byte, types []string) []byte {
+	for i, t := range types {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, t...)
+	}
+	return b
+}
+
+// hasTagValueAppender returns if the value is appended as a string by AppendTagValue, rather than its default
+// encoding, for use by structured formatters.
+func hasTagValueAppender(v any) bool {
+	if len(tagValueAppenders) > 0 {
+		if _, ok := tagValueAppenders[reflect.TypeOf(v)]; ok {
+			return true
+		}
+	}
+	switch v.(type) {
+	case time.Time, time.Duration, []byte, error, fmt.Stringer:
+		return true
+	}
+	return false
+}
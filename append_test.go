@@ -0,0 +1,101 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type point struct {
+	x, y int
+}
+
+type stringerError struct{}
+
+func (stringerError) Error() string  { return "error text" }
+func (stringerError) String() string { return "string text" }
+
+func TestAppendTagValue(t *testing.T) {
+	RegisterTagValueAppender(func(b []byte, p point) []byte {
+		return append(b, fmt.Sprintf("(%d,%d)", p.x, p.y)...)
+	})
+	defer delete(tagValueAppenders, reflect.TypeOf(point{}))
+
+	tests := []struct {
+		name  string
+		value any
+		want  string
+	}{
+		{name: "string", value: "value", want: "value"},
+		{name: "int", value: -42, want: "-42"},
+		{name: "uint8", value: uint8(42), want: "42"},
+		{name: "float", value: 1.5, want: "1.5"},
+		{name: "bool", value: true, want: "true"},
+		{name: "time", value: time.Date(2023, 10, 18, 1, 2, 3, 4, time.UTC), want: "2023-10-18T01:02:03.000000004Z"},
+		{name: "duration", value: 1500 * time.Millisecond, want: "1.5s"},
+		{name: "bytes", value: []byte("value"), want: "value"},
+		{name: "error", value: io.EOF, want: "EOF"},
+		{name: "stringer", value: time.January, want: "January"},
+		{name: "stringer error", value: stringerError{}, want: fmt.Sprintf("%v", stringerError{})},
+		{name: "secret", value: Secret("value"), want: Secret("value").String()},
+		{name: "registered", value: point{x: 1, y: 2}, want: "(1,2)"},
+		{name: "default", value: []int{1, 2}, want: "[1 2]"},
+		{name: "nil stringer", value: (*url.URL)(nil), want: "<nil>"},
+		{name: "nil error", value: (*url.Error)(nil), want: "<nil>"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := string(AppendTagValue(nil, tc.value)); got != tc.want {
+				t.Fatalf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+
+	if got := WrapT(io.EOF, "fetching {url}", T("url", (*url.URL)(nil))).Error(); !strings.Contains(got, "fetching <nil>") {
+		t.Fatalf("want nil pointer Tag interpolated as <nil> got %s", got)
+	}
+
+	err := New("base").AddTag("point", point{x: 1, y: 2}).AddTag("at", time.Date(2023, 10, 18, 0, 0, 0, 0, time.UTC))
+	want := `"tags":{"point":"(1,2)","at":"2023-10-18T00:00:00Z"}`
+	if got := JSONFormat(err); !strings.Contains(got, want) {
+		t.Fatalf("want %s in %s", want, got)
+	}
+}
+
+func TestAppendError(t *testing.T) {
+	err := Wrap(io.EOF, "prefix").AddTypes("Permanent", "io").AddTag("key", "value")
+	if got := string(err.current().AppendError(nil)); got != err.current().Error() {
+		t.Fatalf("want %s got %s", err.current().Error(), got)
+	}
+	if got := string(AppendTypes([]byte("types="), []string{"Permanent", "io"})); got != "types=Permanent,io" {
+		t.Fatalf("want types=Permanent,io got %s", got)
+	}
+}
+
+func TestWalkLinks(t *testing.T) {
+	inner := Wrap(io.EOF, "inner")
+	err := Wrap(fmt.Errorf("std wrapped: %w", inner), "outer")
+
+	var prefixes []string
+	WalkLinks(err, func(l *Link) bool {
+		prefixes = append(prefixes, l.Prefix)
+		return true
+	})
+	if want := []string{"", "outer", "", "inner"}; !reflect.DeepEqual(prefixes, want) {
+		t.Fatalf("want %q got %q", want, prefixes)
+	}
+
+	var count int
+	WalkLinks(err, func(*Link) bool {
+		count++
+		return false
+	})
+	if count != 1 {
+		t.Fatalf("want walk to stop after 1 Link got %d", count)
+	}
+}
@@ -56,3 +56,31 @@ func BenchmarkErrorParallelPrintWithTagsAndTypes(b *testing.B) {
 		}
 	})
 }
+
+func BenchmarkErrorLogfmtFormatWithTagsAndTypes(b *testing.B) {
+	err := New("base error").AddTag("key", "value").AddTypes("Permanent", "Other")
+	for i := 0; i < b.N; i++ {
+		_ = LogfmtFormat(err)
+	}
+}
+
+func BenchmarkErrorJSONFormatWithTagsAndTypes(b *testing.B) {
+	err := New("base error").AddTag("key", "value").AddTypes("Permanent", "Other")
+	for i := 0; i < b.N; i++ {
+		_ = JSONFormat(err)
+	}
+}
+
+func BenchmarkErrorAddTag(b *testing.B) {
+	err := New("base error")
+	for i := 0; i < b.N; i++ {
+		_ = err.AddTag("key", "value")
+	}
+}
+
+func BenchmarkErrorAddTagDeepChain(b *testing.B) {
+	err := New("base error").Wrap("1").Wrap("2").Wrap("3").Wrap("4")
+	for i := 0; i < b.N; i++ {
+		_ = err.AddTag("key", "value")
+	}
+}
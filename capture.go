@@ -0,0 +1,133 @@
+package errors
+
+import (
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// Capture contains flags controlling which additional metadata is captured for each Link, see Wrapper.Capture and
+// RegisterCapture. Nothing is captured by default.
+type Capture uint32
+
+const (
+	// CaptureTime captures the wall-clock time each Link is created, see Link.Time and Link.Elapsed.
+	CaptureTime Capture = 1 << iota
+
+	// CaptureGoroutine captures the id of the goroutine each Link is created on, see Link.Goroutine.
+	CaptureGoroutine
+
+	// CaptureLabels captures the pprof labels of the context used to create each Link, see WrapCtx and Link.Labels.
+	CaptureLabels
+)
+
+var capture uint32
+
+// RegisterCapture sets the metadata captured for each Link by all Wrappers, in addition to that set by
+// Wrapper.Capture.
+func RegisterCapture(c Capture) {
+	atomic.StoreUint32(&capture, uint32(c))
+}
+
+// linkMeta contains the optional metadata of a Link.
+type linkMeta struct {
+	time      time.Time
+	root      time.Time
+	goroutine uint64
+	labels    []Tag
+}
+
+// newLinkMeta returns the metadata to capture for a Link, or nil if none.
+func (w *Wrapper) newLinkMeta(ctx context.Context) *linkMeta {
+	c := Capture(atomic.LoadUint32(&capture))
+	if w != nil {
+		c |= w.Capture
+	}
+	if c == 0 {
+		return nil
+	}
+	m := new(linkMeta)
+	if c&CaptureTime != 0 {
+		m.time = time.Now()
+		m.root = m.time
+	}
+	if c&CaptureGoroutine != 0 {
+		m.goroutine = goroutineID()
+	}
+	if c&CaptureLabels != 0 && ctx != nil {
+		pprof.ForLabels(ctx, func(key, value string) bool {
+			m.labels = append(m.labels, Tag{Key: key, Value: value})
+			return true
+		})
+	}
+	return m
+}
+
+// goroutineID returns the id of the current goroutine parsed from its stack trace header eg. `goroutine 18 [running]:`.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	const prefix = "goroutine "
+	if len(b) < len(prefix) {
+		return 0
+	}
+	b = b[len(prefix):]
+	i := 0
+	for i < len(b) && b[i] >= '0' && b[i] <= '9' {
+		i++
+	}
+	id, _ := strconv.ParseUint(string(b[:i]), 10, 64)
+	return id
+}
+
+// Time returns the wall-clock time the Link was created, or the zero time if not captured, see CaptureTime.
+func (l *Link) Time() time.Time {
+	m := l.extension().meta
+	if m == nil {
+		return time.Time{}
+	}
+	return m.time
+}
+
+// Elapsed returns the monotonic time elapsed between the earliest captured time of the Chain's Links, when this Link
+// was added, and this Link, or zero if not captured, see CaptureTime.
+func (l *Link) Elapsed() time.Duration {
+	m := l.extension().meta
+	if m == nil || m.time.IsZero() {
+		return 0
+	}
+	return m.time.Sub(m.root)
+}
+
+// Goroutine returns the id of the goroutine the Link was created on, or zero if not captured, see CaptureGoroutine.
+func (l *Link) Goroutine() uint64 {
+	m := l.extension().meta
+	if m == nil {
+		return 0
+	}
+	return m.goroutine
+}
+
+// Labels returns the pprof labels of the context the Link was created with, or nil if not captured, see
+// CaptureLabels.
+func (l *Link) Labels() []Tag {
+	m := l.extension().meta
+	if m == nil {
+		return nil
+	}
+	return m.labels
+}
+
+// rootTime returns the earliest captured time of the Links and t, the Link being added, which may be earlier than those
+// of the Chain when it was created before them eg. the spawn Link of Go.
+func (c Chain) rootTime(t time.Time) time.Time {
+	for _, l := range c {
+		if m := l.extension().meta; m != nil && !m.time.IsZero() && (t.IsZero() || m.time.Before(t)) {
+			t = m.time
+		}
+	}
+	return t
+}
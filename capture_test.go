@@ -0,0 +1,127 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"runtime/pprof"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCaptureDisabled(t *testing.T) {
+	c := Wrap(io.EOF, "prefix")
+	for _, l := range c {
+		if l.extension().meta != nil || !l.Time().IsZero() || l.Elapsed() != 0 || l.Goroutine() != 0 || l.Labels() != nil {
+			t.Fatal("want no metadata captured by default")
+		}
+	}
+}
+
+func TestCapture(t *testing.T) {
+	w := &Wrapper{Capture: CaptureTime | CaptureGoroutine | CaptureLabels}
+	ctx := pprof.WithLabels(context.Background(), pprof.Labels("request", "42"))
+
+	before := time.Now()
+	c := w.WrapCtx(ctx, io.EOF, "prefix")
+	time.Sleep(time.Millisecond)
+	c = c.Wrap("outer")
+
+	if c[0].Time().Before(before) || c[0].Elapsed() != 0 || c[1].Elapsed() != 0 {
+		t.Fatalf("want root Links created at the same time got %s %s", c[0].Elapsed(), c[1].Elapsed())
+	}
+	if c[2].Elapsed() < time.Millisecond || !c[2].Time().After(c[0].Time()) {
+		t.Fatalf("want outer Link elapsed since root got %s", c[2].Elapsed())
+	}
+	if id := goroutineID(); id == 0 || c[0].Goroutine() != id || c[2].Goroutine() != id {
+		t.Fatalf("want goroutine %d got %d", id, c[0].Goroutine())
+	}
+	if labels := c[1].Labels(); len(labels) != 1 || labels[0] != T("request", "42") {
+		t.Fatalf("want request label got %v", labels)
+	}
+	if c[2].Labels() != nil {
+		t.Fatal("want no labels without a context")
+	}
+
+	var v struct {
+		Links []struct {
+			Time      time.Time         `json:"time"`
+			Elapsed   string            `json:"elapsed"`
+			Goroutine uint64            `json:"goroutine"`
+			Labels    map[string]string `json:"labels"`
+		} `json:"links"`
+	}
+	if err := json.Unmarshal([]byte(JSONFormat(c)), &v); err != nil {
+		t.Fatal(err)
+	}
+	if !v.Links[2].Time.Equal(c[2].Time()) || v.Links[2].Elapsed != c[2].Elapsed().String() ||
+		v.Links[1].Goroutine != c[1].Goroutine() || v.Links[1].Labels["request"] != "42" {
+		t.Fatalf("want metadata serialized got %s", JSONFormat(c))
+	}
+	if s := LogfmtFormat(c); !strings.Contains(s, " label.request=42") || !strings.Contains(s, " elapsed=") {
+		t.Fatalf("want metadata in logfmt got %s", s)
+	}
+	if s := TreeFormat(c); !strings.Contains(s, "{request=42}") || !strings.Contains(s, " goroutine ") {
+		t.Fatalf("want metadata in tree got %s", s)
+	}
+}
+
+func TestRegisterCapture(t *testing.T) {
+	defer RegisterCapture(0)
+	RegisterCapture(CaptureTime)
+
+	c := NewCtx(context.Background(), "new")
+	if c[0].Time().IsZero() || c[0].Goroutine() != 0 {
+		t.Fatal("want only time captured")
+	}
+}
+
+func TestCaptureSpawnLink(t *testing.T) {
+	defer RegisterCapture(0)
+	RegisterCapture(CaptureTime)
+
+	err := <-Go(func() error {
+		time.Sleep(time.Millisecond)
+		return New("child")
+	})
+	c := err.(Chain)
+	spawn := c.current()
+	if spawn.Prefix != goroutinePrefix || !spawn.Time().Before(c[0].Time()) {
+		t.Fatalf("want spawn Link created before the child error got %s", TreeFormat(c))
+	}
+	if spawn.Elapsed() != 0 || c[0].Elapsed() != 0 {
+		t.Fatalf("want no elapsed time for the earliest Links got %s and %s", spawn.Elapsed(), c[0].Elapsed())
+	}
+}
+
+func TestCapturePanic(t *testing.T) {
+	defer RegisterCapture(0)
+	RegisterCapture(CaptureTime | CaptureGoroutine)
+
+	recovered := func() (err error) {
+		defer Recover(&err)
+		panic("boom")
+	}
+	spawned := <-Go(func() error {
+		panic(New("child"))
+	})
+	for name, err := range map[string]error{
+		"FromPanic": FromPanic("boom"),
+		"Recover":   recovered(),
+		"Go":        spawned,
+	} {
+		c := err.(Chain)
+		for _, l := range c {
+			if l.Time().IsZero() || l.Goroutine() == 0 {
+				t.Errorf("%s: want metadata captured for every Link got %s", name, TreeFormat(c))
+			}
+		}
+	}
+
+	RegisterCapture(0)
+	w := &Wrapper{Capture: CaptureGoroutine}
+	if c := FromPanic(w.New("child")); c.current().Goroutine() == 0 {
+		t.Fatalf("want panic Link captured using the Wrapper of the Chain got %s", TreeFormat(c))
+	}
+}
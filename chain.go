@@ -2,9 +2,6 @@ package errors
 
 import (
 	stderrors "errors"
-	"fmt"
-	"strconv"
-	"strings"
 
 	runtimeext "github.com/go-playground/pkg/v5/runtime"
 	unsafeext "github.com/go-playground/pkg/v5/unsafe"
@@ -28,14 +25,6 @@ type Tag struct {
 	Value any
 }
 
-func newLink(err error, prefix string, skipFrames int) *Link {
-	return &Link{
-		Err:    err,
-		Prefix: prefix,
-		Source: runtimeext.StackLevel(skipFrames),
-	}
-}
-
 // Chain contains the chained errors, the links, of the chains if you will
 type Chain []*Link
 
@@ -61,105 +50,98 @@ type Link struct {
 
 	// Source contains the name, file and lines obtained from the stack trace
 	Source runtimeext.Frame
-}
 
-// Error prints out a single Link in the Chains error.
-func (l *Link) Error() string {
-	return unsafeext.BytesToString(l.formatError(make([]byte, 0, 64)))
+	ext *linkExt
 }
 
-// formatError prints a single Links error
-func (l *Link) formatError(b []byte) []byte {
-	var funcName string
+// linkExt contains the rarely set data of a Link, allocated only when the Link has any so that Links created without it
+// are smaller. It is shared by copies of the Link, see Chain.mutable, and so is never modified once set, see
+// Link.extend, except to end building.
+type linkExt struct {
+	stack        []byte
+	origin       *runtimeext.Frame
+	meta         *linkMeta
+	wrapper      *Wrapper
+	template     string
+	templateTags int
+	public       *Public
+	building     bool
+}
 
-	b = append(b, "source="...)
-	idx := strings.LastIndexByte(l.Source.Frame.Function, '.')
-	if idx == -1 {
-		b = append(b, l.Source.File()...)
-	} else {
-		funcName = l.Source.Frame.Function[idx+1:]
-		remaining := l.Source.Frame.Function[:idx]
-
-		idx = strings.LastIndexByte(remaining, '/')
-		if idx > -1 {
-			b = append(b, l.Source.Frame.Function[:idx+1]...)
-			remaining = l.Source.Frame.Function[idx+1:]
-		}
+// noExt is the extension of Links without one, it must not be modified.
+var noExt = new(linkExt)
 
-		idx = strings.IndexByte(remaining, '.')
-		if idx == -1 {
-			b = append(b, remaining...)
-		} else {
-			b = append(b, remaining[:idx]...)
-		}
-		b = append(b, '/')
-		b = append(b, l.Source.File()...)
-	}
-	b = append(b, ':')
-	b = strconv.AppendInt(b, int64(l.Source.Line()), 10)
-	if funcName != "" {
-		b = append(b, ':')
-		b = append(b, funcName...)
+// extension returns the Links extension for reading.
+func (l *Link) extension() *linkExt {
+	if l.ext == nil {
+		return noExt
 	}
-	b = append(b, ' ')
-	b = append(b, "error="...)
+	return l.ext
+}
 
-	if l.Prefix != "" {
-		b = append(b, l.Prefix...)
+// extend sets a copy of the Links extension, or a new one, on the Link and returns it so it can be modified.
+func (l *Link) extend() *linkExt {
+	ext := new(linkExt)
+	if l.ext != nil {
+		*ext = *l.ext
 	}
+	l.ext = ext
+	return ext
+}
 
-	if l.Err != nil {
-		if l.Prefix != "" {
-			b = append(b, ": "...)
-		}
-		b = append(b, l.Err.Error()...)
+// Origin returns the frame the Link was created in when it was skipped, by a FrameFilter, to choose the application
+// frame used as the Source.
+func (l *Link) Origin() (runtimeext.Frame, bool) {
+	origin := l.extension().origin
+	if origin == nil {
+		return runtimeext.Frame{}, false
 	}
+	return *origin, true
+}
+
+// Stack returns the full goroutine stack captured when the Link was created from a panic, otherwise nil.
+func (l *Link) Stack() []byte {
+	return l.extension().stack
+}
+
+// Error prints out a single Link in the Chains error.
+func (l *Link) Error() string {
+	return unsafeext.BytesToString(l.AppendError(make([]byte, 0, 64)))
+}
+
+// AppendError appends the single Links error, as printed by Error, to b.
+func (l *Link) AppendError(b []byte) []byte {
+	b = append(b, "source="...)
+	b = AppendSource(b, l.Source, sourcePathPolicy)
+	b = append(b, ' ')
+	b = append(b, "error="...)
+	b = l.appendMessage(b)
 
 	for _, tag := range l.Tags {
 		b = append(b, ' ')
 		b = append(b, tag.Key...)
 		b = append(b, '=')
-		switch t := tag.Value.(type) {
-		case string:
-			b = append(b, t...)
-		case int:
-			b = strconv.AppendInt(b, int64(t), 10)
-		case int8:
-			b = strconv.AppendInt(b, int64(t), 10)
-		case int16:
-			b = strconv.AppendInt(b, int64(t), 10)
-		case int32:
-			b = strconv.AppendInt(b, int64(t), 10)
-		case int64:
-			b = strconv.AppendInt(b, t, 10)
-		case uint:
-			b = strconv.AppendUint(b, uint64(t), 10)
-		case uint8:
-			b = strconv.AppendUint(b, uint64(t), 10)
-		case uint16:
-			b = strconv.AppendUint(b, uint64(t), 10)
-		case uint32:
-			b = strconv.AppendUint(b, uint64(t), 10)
-		case uint64:
-			b = strconv.AppendUint(b, t, 10)
-		case float32:
-			b = strconv.AppendFloat(b, float64(t), 'g', -1, 32)
-		case float64:
-			b = strconv.AppendFloat(b, t, 'g', -1, 64)
-		case bool:
-			b = strconv.AppendBool(b, t)
-		default:
-			b = append(b, fmt.Sprintf("%v", tag.Value)...)
-		}
+		b = AppendTagValue(b, tag.RedactedValue())
 	}
 
 	if len(l.Types) > 0 {
 		b = append(b, " types="...)
-		for _, t := range l.Types {
-			b = append(b, t...)
-			b = append(b, ',')
+		b = AppendTypes(b, l.Types)
+	}
+	return b
+}
+
+// appendMessage appends the Links prefix and error, if any, with sensitive information scrubbed.
+func (l *Link) appendMessage(b []byte) []byte {
+	if l.Prefix != "" {
+		b = append(b, scrub(l.Prefix)...)
+	}
+
+	if l.Err != nil {
+		if l.Prefix != "" {
+			b = append(b, ": "...)
 		}
-		b = b[:len(b)-1]
+		b = append(b, scrub(l.Err.Error())...)
 	}
 	return b
 }
@@ -169,12 +151,53 @@ func (c Chain) current() *Link {
 	return c[len(c)-1]
 }
 
-// AddTags allows the addition of multiple tags
-func (c Chain) AddTags(tags ...Tag) Chain {
+// mutable returns a copy of the Chain and its current Link, which can then be modified without affecting any other
+// Chain sharing the Link. The Chain is returned as is when its current Link is still being built by the helpers.
+//
+// The slice of Links must be copied, not only the current Link, as Chains derived from the same parent share its
+// underlying array; it only contains pointers so copying it is a single small allocation.
+func (c Chain) mutable() (Chain, *Link) {
 	l := c.current()
-	if len(l.Tags) == 0 {
-		l.Tags = make([]Tag, 0, len(tags))
+	if l.extension().building {
+		return c, l
+	}
+	nl := *l
+	nl.Tags = nl.Tags[:len(nl.Tags):len(nl.Tags)]
+	nl.Types = nl.Types[:len(nl.Types):len(nl.Types)]
+	nc := make(Chain, len(c))
+	copy(nc, c)
+	nc[len(nc)-1] = &nl
+	return nc, &nl
+}
+
+// append returns a new Chain with the Link added, never sharing the underlying array with the original.
+func (c Chain) append(l *Link) Chain {
+	nc := make(Chain, len(c)+1)
+	copy(nc, c)
+	nc[len(c)] = l
+	return nc
+}
+
+// Clone returns a deep copy of the Chain, including its Links and their Types and Tags.
+//
+// As Chains have value semantics this is not required when using AddTags, AddTypes or Wrap, only when directly
+// modifying Links.
+func (c Chain) Clone() Chain {
+	nc := make(Chain, len(c))
+	for i, l := range c {
+		nl := *l
+		nl.Types = append([]string(nil), l.Types...)
+		nl.Tags = append([]Tag(nil), l.Tags...)
+		nc[i] = &nl
 	}
+	return nc
+}
+
+// AddTags allows the addition of multiple tags
+//
+// Chains have value semantics, the Chain is not modified and a new Chain is returned.
+func (c Chain) AddTags(tags ...Tag) Chain {
+	c, l := c.mutable()
 	l.Tags = append(l.Tags, tags...)
 	return c
 }
@@ -185,15 +208,22 @@ func (c Chain) AddTag(key string, value any) Chain {
 }
 
 // AddTypes sets one or more categorized types on the Link error
+//
+// Chains have value semantics, the Chain is not modified and a new Chain is returned.
 func (c Chain) AddTypes(typ ...string) Chain {
-	l := c.current()
+	c, l := c.mutable()
 	l.Types = append(l.Types, typ...)
+	notifyTypes(c, l, l.Types[len(l.Types)-len(typ):])
 	return c
 }
 
 // Wrap adds another contextual prefix to the error chain
 func (c Chain) Wrap(prefix string) Chain {
-	return wrap(c, prefix, 3)
+	w := std
+	if len(c) > 0 {
+		w = c.current().extension().wrapper
+	}
+	return w.wrap(c, prefix, 3)
 }
 
 // Unwrap returns the result of calling the Unwrap method on an error, if the errors
@@ -212,10 +242,20 @@ func (c Chain) Unwrap() error {
 }
 
 // Is reports whether any error in error chain matches target.
+//
+// A TypeTarget matches if any Link of the Chain carries the type.
 func (c Chain) Is(target error) bool {
 	if len(c) == 0 {
 		return false
 	}
+	if typ, ok := target.(TypeTarget); ok {
+		for i := len(c) - 1; i >= 0; i-- {
+			if c[i].hasType(string(typ)) {
+				return true
+			}
+		}
+		return false
+	}
 	if innerErr, ok := target.(Chain); ok {
 		if len(innerErr) == 0 {
 			return false
@@ -241,10 +281,21 @@ func (c Chain) Is(target error) bool {
 //
 // As panics if target is not a non-nil pointer to either a type that implements
 // error, or to any interface type.
+//
+// A *LinkTarget matches the first Link of the Chain, from the outermost, meeting its criteria and sets its Link.
 func (c Chain) As(target any) bool {
 	if len(c) == 0 {
 		return false
 	}
+	if t, ok := target.(*LinkTarget); ok {
+		for i := len(c) - 1; i >= 0; i-- {
+			if t.matches(c[i]) {
+				t.Link = c[i]
+				return true
+			}
+		}
+		return false
+	}
 	return stderrors.As(c[0].Err, target)
 }
 
@@ -252,7 +303,7 @@ func defaultFormatFn(c Chain) string {
 	b := make([]byte, 0, len(c)*192)
 
 	for i := 0; i < len(c); i++ {
-		b = c[i].formatError(b)
+		b = c[i].AppendError(b)
 		b = append(b, '\n')
 	}
 	return unsafeext.BytesToString(b[:len(b)-1])
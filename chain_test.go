@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestChainValueSemantics(t *testing.T) {
+	base := Wrap(io.EOF, "base").AddTag("key", "value")
+	base = base.Wrap("grow").Wrap("capacity")
+
+	a := base.Wrap("a")
+	b := base.Wrap("b")
+	if a.current().Prefix != "a" || b.current().Prefix != "b" {
+		t.Fatalf("want independent Links got %q and %q", a.current().Prefix, b.current().Prefix)
+	}
+
+	tagged := base.AddTag("derived", true).AddTypes("Permanent")
+	if LookupTag(base, "derived") != nil || HasType(base, "Permanent") {
+		t.Fatalf("want parent unaffected got %s", base)
+	}
+	if LookupTag(a, "derived") != nil || HasType(a, "Permanent") {
+		t.Fatalf("want sibling unaffected got %s", a)
+	}
+	if LookupTag(tagged, "derived") != true || !HasType(tagged, "Permanent") || LookupTag(tagged, "key") != "value" {
+		t.Fatalf("want derived Chain to contain all tags and types got %s", tagged)
+	}
+
+	clone := tagged.Clone()
+	clone.current().Tags[0].Value = false
+	clone[0].Prefix = "modified"
+	if LookupTag(tagged, "derived") != true || tagged[0].Prefix == "modified" {
+		t.Fatalf("want clone to be independent got %s", tagged)
+	}
+}
+
+func TestChainConcurrentDerivation(t *testing.T) {
+	base := Wrap(io.EOF, "base").AddTag("key", "value").AddTypes("Transient")
+	length := len(base)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c := base.AddTag("id", i).AddTypes("Derived").Wrap("derived").AddTag("outer", i)
+			_ = c.Error()
+			if LookupTag(c, "id") != i || LookupTag(c, "outer") != i {
+				t.Errorf("want tags of %d got %s", i, c)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if len(base) != length || LookupTag(base, "id") != nil || HasType(base, "Derived") {
+		t.Fatalf("want base Chain unaffected got %s", base)
+	}
+}
+
+func TestChainWrapEmpty(t *testing.T) {
+	c := Chain{}.Wrap("prefix")
+	if len(c) != 1 || c[0].Prefix != "prefix" {
+		t.Fatalf("want a single Link got %#v", c)
+	}
+}
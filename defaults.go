@@ -0,0 +1,55 @@
+package errors
+
+import (
+	"os"
+	"runtime/debug"
+	"strconv"
+)
+
+var defaultTags []Tag
+
+// RegisterDefaultTags adds process-level Tags, such as the service name and build revision, which are included once by
+// the structured formatters, JSONFormat and LogfmtFormat, rather than being added to every Link. They are not used
+// when calculating fingerprints.
+//
+//	errors.RegisterDefaultTags(errors.T("service", "billing"), errors.HostnameTag())
+//	errors.RegisterDefaultTags(errors.BuildInfoTags()...)
+//
+// NOTE: this is not concurrency safe and should be called during initialization.
+func RegisterDefaultTags(tags ...Tag) {
+	defaultTags = append(defaultTags, tags...)
+}
+
+// DefaultTags returns the registered default Tags, see RegisterDefaultTags.
+func DefaultTags() []Tag {
+	return append([]Tag(nil), defaultTags...)
+}
+
+// BuildInfoTags returns the main module version, and the VCS revision and modified flag when stamped, as the
+// version, revision and modified Tags for use with RegisterDefaultTags.
+func BuildInfoTags() []Tag {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return nil
+	}
+	var tags []Tag
+	if bi.Main.Version != "" {
+		tags = append(tags, T("version", bi.Main.Version))
+	}
+	for _, s := range bi.Settings {
+		switch s.Key {
+		case "vcs.revision":
+			tags = append(tags, T("revision", s.Value))
+		case "vcs.modified":
+			modified, _ := strconv.ParseBool(s.Value)
+			tags = append(tags, T("modified", modified))
+		}
+	}
+	return tags
+}
+
+// HostnameTag returns the hostname, as reported by os.Hostname, as the host Tag for use with RegisterDefaultTags.
+func HostnameTag() Tag {
+	host, _ := os.Hostname()
+	return T("host", host)
+}
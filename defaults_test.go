@@ -0,0 +1,70 @@
+package errors
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDefaultTags(t *testing.T) {
+	c := Wrap(io.EOF, "prefix").AddTag("key", "value")
+	fingerprint := Fingerprint(c)
+
+	defer func() { defaultTags = nil }()
+	RegisterDefaultTags(T("service", "billing"), T("token", Secret("abc")))
+
+	if len(c[1].Tags) != 1 {
+		t.Fatalf("want default tags not added to Links got %v", c[1].Tags)
+	}
+	if Fingerprint(c) != fingerprint {
+		t.Fatal("want default tags excluded from the fingerprint")
+	}
+
+	var v struct {
+		Tags  map[string]any `json:"tags"`
+		Links []struct {
+			Tags map[string]any `json:"tags"`
+		} `json:"links"`
+	}
+	if err := json.Unmarshal([]byte(JSONFormat(c)), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Tags["service"] != "billing" || !strings.HasPrefix(v.Tags["token"].(string), "[REDACTED:") {
+		t.Fatalf("want redacted default tags got %v", v.Tags)
+	}
+	if _, ok := v.Links[1].Tags["service"]; ok {
+		t.Fatalf("want default tags once got %s", JSONFormat(c))
+	}
+
+	for _, line := range strings.Split(LogfmtFormat(c), "\n") {
+		if !strings.Contains(line, " service=billing token=[REDACTED:") {
+			t.Fatalf("want default tags on every line got %s", line)
+		}
+	}
+	if strings.Contains(c.Error(), "billing") || strings.Contains(TreeFormat(c), "billing") {
+		t.Fatal("want default tags only in structured output")
+	}
+}
+
+func TestDefaultTagSources(t *testing.T) {
+	host, _ := os.Hostname()
+	if tag := HostnameTag(); tag.Key != "host" || tag.Value != host {
+		t.Fatalf("want host tag got %v", tag)
+	}
+	for _, tag := range BuildInfoTags() {
+		switch tag.Key {
+		case "version", "revision":
+			if _, ok := tag.Value.(string); !ok {
+				t.Errorf("want string %s got %T", tag.Key, tag.Value)
+			}
+		case "modified":
+			if _, ok := tag.Value.(bool); !ok {
+				t.Errorf("want bool modified got %T", tag.Value)
+			}
+		default:
+			t.Errorf("unexpected build info tag %s", tag.Key)
+		}
+	}
+}
@@ -1,12 +1,14 @@
 package errors
 
 import (
+	"context"
 	stderrors "errors"
 	"fmt"
 	"reflect"
 )
 
 type unwrap interface{ Unwrap() error }
+type unwrapMulti interface{ Unwrap() []error }
 type is interface{ Is(error) bool }
 type as interface{ As(any) bool }
 
@@ -37,53 +39,65 @@ func RegisterErrorFormatFn(fn ErrorFormatFn) {
 
 // New creates an error with the provided text and automatically wraps it with line information.
 func New(s string) Chain {
-	return wrap(stderrors.New(s), "", 3)
+	return std.wrap(stderrors.New(s), "", 3)
 }
 
 // Newf creates an error with the provided text and automatically wraps it with line information.
 // it also accepts a variadic for optional message formatting.
 func Newf(format string, a ...any) Chain {
-	return wrap(fmt.Errorf(format, a...), "", 3)
+	return std.wrap(fmt.Errorf(format, a...), "", 3)
 }
 
 // Wrap encapsulates the error, stores a contextual prefix and automatically obtains
 // a stack trace.
 func Wrap(err error, prefix string) Chain {
-	return wrap(err, prefix, 3)
+	return std.wrap(err, prefix, 3)
 }
 
 // Wrapf encapsulates the error, stores a contextual prefix and automatically obtains
 // a stack trace.
 // it also accepts a variadic for prefix formatting.
 func Wrapf(err error, prefix string, a ...any) Chain {
-	return wrap(err, fmt.Sprintf(prefix, a...), 3)
+	return std.wrap(err, fmt.Sprintf(prefix, a...), 3)
 }
 
 // WrapSkipFrames is a special version of Wrap that skips extra n frames when determining error location.
-// Normally only used when wrapping the library
+// Normally only used when wrapping the library, see MarkHelper which does not require counting frames.
 func WrapSkipFrames(err error, prefix string, n uint) Chain {
-	return wrap(err, prefix, int(n)+3)
+	return std.wrap(err, prefix, int(n)+3)
 }
 
-func wrap(err error, prefix string, skipFrames int) (c Chain) {
+// NewCtx is the same as New and captures the pprof labels of the context, see CaptureLabels.
+func NewCtx(ctx context.Context, s string) Chain {
+	return std.wrapCtx(ctx, stderrors.New(s), "", 3)
+}
+
+// WrapCtx is the same as Wrap and captures the pprof labels of the context, see CaptureLabels.
+func WrapCtx(ctx context.Context, err error, prefix string) Chain {
+	return std.wrapCtx(ctx, err, prefix, 3)
+}
+
+// WrapIf is the same as Wrap except it returns nil, rather than panicking, when err is nil. It returns an error, not a
+// Chain, so that a nil result is never a non-nil error interface containing a nil Chain.
+func WrapIf(err error, prefix string) error {
 	if err == nil {
-		panic("errors: Wrap|Wrapf called with nil error")
-	}
-	var ok bool
-	if c, ok = err.(Chain); ok {
-		c = append(c, newLink(nil, prefix, skipFrames))
-	} else {
-		c = Chain{newLink(err, "", skipFrames)}
-		for _, h := range helpers {
-			if !h(c, err) {
-				break
-			}
-		}
-		if prefix != "" {
-			c = append(c, &Link{Prefix: prefix, Source: c[0].Source})
-		}
+		return nil
 	}
-	return
+	return std.wrap(err, prefix, 3)
+}
+
+// Annotate wraps the error pointed to by err, when it is not nil, with the formatted prefix. Any Tag arguments are
+// added as Tags of the Link rather than used for formatting. It is intended to be deferred to wrap a named return, eg.
+//
+//	func load(name string) (err error) {
+//		defer errors.Annotate(&err, "loading config %s", name, errors.T("name", name))
+//		...
+//	}
+//
+// The source is the function returning the error rather than the deferred function, so Annotate must be called
+// directly using defer.
+func Annotate(err *error, format string, a ...any) {
+	std.annotate(err, format, a, 4)
 }
 
 // Cause extracts and returns the root wrapped error (the naked error with no additional information)
@@ -104,16 +118,14 @@ func Cause(err error) error {
 }
 
 // HasType is a helper function that will recurse up from the root error and check that the provided type
-// is present using an equality check
+// is present using an equality check, or is an ancestor of a present Kind.
 func HasType(err error, typ string) bool {
 	for {
 		switch t := err.(type) {
 		case Chain:
 			for i := len(t) - 1; i >= 0; i-- {
-				for j := 0; j < len(t[i].Types); j++ {
-					if t[i].Types[j] == typ {
-						return true
-					}
+				if t[i].hasType(typ) {
+					return true
 				}
 			}
 			err = t[0].Err
@@ -121,20 +133,86 @@ func HasType(err error, typ string) bool {
 		case unwrap:
 			err = t.Unwrap()
 			continue
+		case unwrapMulti:
+			for _, e := range t.Unwrap() {
+				if HasType(e, typ) {
+					return true
+				}
+			}
 		}
 		return false
 	}
 }
 
-// LookupTag recursively searches for the provided tag and returns its value or nil
+// WalkLinks calls fn for every Link of every Chain contained in the error, including those wrapped by other error
+// types, until fn returns false. The Links of each Chain are visited in order from the root Link, with the outermost
+// Chain visited first.
+func WalkLinks(err error, fn func(*Link) bool) {
+	walkLinks(err, false, fn)
+}
+
+// walkLinks is WalkLinks, visiting the Links of each Chain from the outermost Link when outerFirst is set, the order
+// used by LookupTag.
+func walkLinks(err error, outerFirst bool, fn func(*Link) bool) bool {
+	for {
+		switch t := err.(type) {
+		case Chain:
+			for i := range t {
+				l := t[i]
+				if outerFirst {
+					l = t[len(t)-1-i]
+				}
+				if !fn(l) {
+					return false
+				}
+			}
+			err = t[0].Err
+			continue
+		case unwrap:
+			err = t.Unwrap()
+			continue
+		case unwrapMulti:
+			for _, e := range t.Unwrap() {
+				if !walkLinks(e, outerFirst, fn) {
+					return false
+				}
+			}
+		}
+		return true
+	}
+}
+
+// LookupTag recursively searches for the provided tag and returns its value or nil.
+//
+// Sensitive values are returned as a SecretValue, see LookupTagUnredacted.
 func LookupTag(err error, key string) any {
+	if tag, ok := lookupTag(err, key); ok {
+		return tag.RedactedValue()
+	}
+	return nil
+}
+
+// LookupTagUnredacted recursively searches for the provided tag and returns its original unredacted value or nil.
+// This should only be used by trusted code.
+func LookupTagUnredacted(err error, key string) any {
+	tag, ok := lookupTag(err, key)
+	if !ok {
+		return nil
+	}
+	if s, ok := tag.Value.(SecretValue); ok {
+		return s.Unredacted()
+	}
+	return tag.Value
+}
+
+func lookupTag(err error, key string) (Tag, bool) {
 	for {
 		switch t := err.(type) {
 		case Chain:
 			for i := len(t) - 1; i >= 0; i-- {
 				for j := 0; j < len(t[i].Tags); j++ {
 					if t[i].Tags[j].Key == key {
-						return t[i].Tags[j].Value
+						return t[i].Tags[j], true
 					}
 				}
 			}
@@ -143,8 +221,14 @@ func LookupTag(err error, key string) any {
 		case unwrap:
 			err = t.Unwrap()
 			continue
+		case unwrapMulti:
+			for _, e := range t.Unwrap() {
+				if tag, ok := lookupTag(e, key); ok {
+					return tag, true
+				}
+			}
 		}
-		return nil
+		return Tag{}, false
 	}
 }
 
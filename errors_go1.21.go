@@ -0,0 +1,11 @@
+//go:build go1.21
+// +build go1.21
+
+package errors
+
+import "log/slog"
+
+// LogValue ensures the value is redacted when logged using slog.
+func (s SecretValue) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
@@ -225,6 +225,7 @@ func TestCauseStdErrorsMixed(t *testing.T) {
 }
 
 func TestHelpers(t *testing.T) {
+	defer func(registered []Helper) { helpers = registered }(helpers)
 	fn := func(w Chain, _ error) (cont bool) {
 		_ = w.AddTypes("Test").AddTags(T("test", "tag")).AddTag("foo", "bar")
 		return false
@@ -237,6 +238,47 @@ func TestHelpers(t *testing.T) {
 	}
 }
 
+func TestRunHelpersExtension(t *testing.T) {
+	defer func(registered []Helper) { helpers = registered }(helpers)
+	RegisterHelper(func(c Chain, _ error) bool {
+		_ = c.WithPublicMessage("public")
+		return false
+	})
+
+	for _, c := range []Chain{{&Link{Err: io.EOF}}, {&Link{Err: io.EOF, ext: &linkExt{template: "{a}"}}}} {
+		ext := c[0].ext
+		RunHelpers(c, io.EOF)
+		if p, ok := c[0].Public(); !ok || p.Message != "public" || c[0].extension().building {
+			t.Fatal("want the extension of the Link modified in place while building")
+		}
+		if ext != nil && (ext.public != nil || ext.building || c[0].Template() != "{a}") {
+			t.Fatal("want the existing extension copied rather than modified")
+		}
+	}
+	if buildingExt.public != nil || !buildingExt.building {
+		t.Fatal("want the building extension unmodified")
+	}
+}
+
+func TestRunHelpersPanic(t *testing.T) {
+	defer func(registered []Helper) { helpers = registered }(helpers)
+	RegisterHelper(func(Chain, error) bool {
+		panic("helper")
+	})
+
+	c := Chain{&Link{Err: io.EOF}}
+	func() {
+		defer func() { _ = recover() }()
+		RunHelpers(c, io.EOF)
+	}()
+	if c[0].extension().building {
+		t.Fatal("want Link no longer building after a helper panics")
+	}
+	if c.AddTag("key", "value")[0] == c[0] {
+		t.Fatal("want a new Link when adding Tags")
+	}
+}
+
 func TestLookupTag(t *testing.T) {
 	key := "Key"
 	value := "Value"
@@ -318,6 +360,7 @@ func TestAs(t *testing.T) {
 }
 
 func TestCustomFormatFn(t *testing.T) {
+	defer RegisterErrorFormatFn(defaultFormatFn)
 	RegisterErrorFormatFn(func(c Chain) (s string) {
 		return c[0].Err.Error()
 	})
@@ -327,3 +370,43 @@ func TestCustomFormatFn(t *testing.T) {
 		t.Errorf("Expected output of 'EOF'")
 	}
 }
+
+func TestWrapIf(t *testing.T) {
+	if err := WrapIf(nil, "prefix"); err != nil {
+		t.Fatalf("want nil got %#v", err)
+	}
+	err := WrapIf(io.EOF, "prefix")
+	c, ok := err.(Chain)
+	if !ok || len(c) != 2 || c[1].Prefix != "prefix" || c[1].Source.Function() != "TestWrapIf" {
+		t.Fatalf("want wrapped Chain got %#v", err)
+	}
+}
+
+func annotated(fail bool) (err error) {
+	defer Annotate(&err, "loading config %s", "app.yaml", T("name", "app.yaml"))
+	if fail {
+		return io.EOF
+	}
+	return nil
+}
+
+func TestAnnotate(t *testing.T) {
+	if err := annotated(false); err != nil {
+		t.Fatalf("want nil got %#v", err)
+	}
+
+	err := annotated(true)
+	c, ok := err.(Chain)
+	if !ok || len(c) != 2 {
+		t.Fatalf("want wrapped Chain got %#v", err)
+	}
+	if c[1].Prefix != "loading config app.yaml" {
+		t.Errorf("want formatted prefix got %q", c[1].Prefix)
+	}
+	if c[1].Source.Function() != "annotated" {
+		t.Errorf("want source of the annotated function got %s", c[1].Source.Function())
+	}
+	if LookupTag(err, "name") != "app.yaml" {
+		t.Errorf("want name tag got %s", err)
+	}
+}
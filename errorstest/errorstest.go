@@ -0,0 +1,210 @@
+// Package errorstest provides assertion and golden file helpers for testing errors.Chain errors.
+package errorstest
+
+import (
+	stderrors "errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+)
+
+// HasType asserts that the error contains the provided type, see errors.HasType.
+func HasType(tb testing.TB, err error, typ string) bool {
+	tb.Helper()
+	if errors.HasType(err, typ) {
+		return true
+	}
+	tb.Errorf("expected error to have type %q\n%s", typ, Tree(err))
+	return false
+}
+
+// HasTag asserts that the error contains the provided tag with a value deeply equal to value.
+// Sensitive values are compared unredacted.
+func HasTag(tb testing.TB, err error, key string, value any) bool {
+	tb.Helper()
+	got := errors.LookupTagUnredacted(err, key)
+	if reflect.DeepEqual(got, value) {
+		return true
+	}
+	tb.Errorf("expected error to have tag %s=%v got %v\n%s", key, value, got, Tree(err))
+	return false
+}
+
+// SourceIs asserts that the source function of the errors outermost Link is funcName.
+//
+// funcName can either be the function name only eg. `Load` or fully qualified eg. `example.com/pkg.(*Store).Load`.
+func SourceIs(tb testing.TB, err error, funcName string) bool {
+	tb.Helper()
+	c, ok := asChain(err)
+	if !ok {
+		tb.Errorf("expected error to be an errors.Chain got %T\n%s", err, Tree(err))
+		return false
+	}
+	source := c[len(c)-1].Source
+	got := source.Function()
+	if strings.ContainsAny(funcName, "./") {
+		got = source.Frame.Function
+	}
+	if got == funcName {
+		return true
+	}
+	tb.Errorf("expected error source to be %s got %s\n%s", funcName, got, Tree(err))
+	return false
+}
+
+// CauseIs asserts that the root cause of the error, see errors.Cause, is or wraps target.
+func CauseIs(tb testing.TB, err error, target error) bool {
+	tb.Helper()
+	cause := errors.Cause(err)
+	if stderrors.Is(cause, target) {
+		return true
+	}
+	tb.Errorf("expected error cause to be %v got %v\n%s", target, cause, Tree(err))
+	return false
+}
+
+// ChainLen asserts that the error contains a Chain with n Links.
+func ChainLen(tb testing.TB, err error, n int) bool {
+	tb.Helper()
+	c, ok := asChain(err)
+	if !ok {
+		tb.Errorf("expected error to be an errors.Chain got %T\n%s", err, Tree(err))
+		return false
+	}
+	if len(c) == n {
+		return true
+	}
+	tb.Errorf("expected error chain length of %d got %d\n%s", n, len(c), Tree(err))
+	return false
+}
+
+// TemplatesComplete asserts that every Link of the error created using errors.NewT or errors.WrapT has a Tag for each
+// placeholder of its template and uses every Tag it was created with, see errors.CheckTemplate.
+func TemplatesComplete(tb testing.TB, err error) bool {
+	tb.Helper()
+	ok := true
+	errors.WalkLinks(err, func(l *errors.Link) bool {
+		if e := l.CheckTemplate(); e != nil {
+			tb.Errorf("expected complete template at %s:%d: %s\n%s", l.Source.File(), l.Source.Line(), e, Tree(err))
+			ok = false
+		}
+		return true
+	})
+	return ok
+}
+
+// asChain returns the first Chain found in the error tree.
+func asChain(err error) (errors.Chain, bool) {
+	var c errors.Chain
+	ok := stderrors.As(err, &c)
+	return c, ok
+}
+
+// Tree returns a readable, indented tree of the error including each Links source, types and tags.
+func Tree(err error) string {
+	var b strings.Builder
+	appendTree(&b, err, "")
+	return b.String()
+}
+
+func appendTree(b *strings.Builder, err error, indent string) {
+	for err != nil {
+		switch t := err.(type) {
+		case errors.Chain:
+			b.WriteString(indent)
+			b.WriteString("Chain\n")
+			for i := len(t) - 1; i >= 0; i-- {
+				appendLink(b, t[i], i, i == 0, indent)
+			}
+			// the root error is printed as part of the first Link, only continue if it wraps other errors.
+			indent += "    "
+			switch root := t[0].Err.(type) {
+			case interface{ Unwrap() error }:
+				err = root.Unwrap()
+				if err != nil {
+					b.WriteString(indent)
+					b.WriteString("caused by:\n")
+				}
+			case interface{ Unwrap() []error }:
+				for _, e := range root.Unwrap() {
+					appendTree(b, e, indent)
+				}
+				return
+			default:
+				return
+			}
+		case interface{ Unwrap() error }:
+			b.WriteString(indent)
+			b.WriteString("wrapped: ")
+			b.WriteString(err.Error())
+			b.WriteByte('\n')
+			err = t.Unwrap()
+		case interface{ Unwrap() []error }:
+			b.WriteString(indent)
+			b.WriteString("joined: ")
+			b.WriteString(strconv.Itoa(len(t.Unwrap())))
+			b.WriteString(" errors\n")
+			for _, e := range t.Unwrap() {
+				appendTree(b, e, indent+"    ")
+			}
+			return
+		default:
+			b.WriteString(indent)
+			b.WriteString("error: ")
+			b.WriteString(err.Error())
+			b.WriteByte('\n')
+			return
+		}
+	}
+}
+
+func appendLink(b *strings.Builder, l *errors.Link, idx int, last bool, indent string) {
+	branch, cont := "├── ", "│       "
+	if last {
+		branch, cont = "└── ", "        "
+	}
+	b.WriteString(indent)
+	b.WriteString(branch)
+	b.WriteByte('[')
+	b.WriteString(strconv.Itoa(idx))
+	b.WriteString("] ")
+	b.WriteString(l.Source.Frame.Function)
+	b.WriteString(" (")
+	b.WriteString(l.Source.File())
+	b.WriteByte(':')
+	b.WriteString(strconv.Itoa(l.Source.Line()))
+	b.WriteString(")\n")
+
+	if l.Prefix != "" {
+		b.WriteString(indent + cont + "prefix: ")
+		b.WriteString(l.Prefix)
+		b.WriteByte('\n')
+	}
+	if l.Err != nil {
+		b.WriteString(indent + cont + "error: ")
+		switch l.Err.(type) {
+		case interface{ Unwrap() error }, interface{ Unwrap() []error }:
+			// the wrapped errors are printed separately
+			b.WriteString(fmt.Sprintf("(%T)", l.Err))
+		default:
+			b.WriteString(l.Err.Error())
+		}
+		b.WriteByte('\n')
+	}
+	if len(l.Types) > 0 {
+		b.WriteString(indent + cont + "types: ")
+		b.WriteString(strings.Join(l.Types, ", "))
+		b.WriteByte('\n')
+	}
+	for _, tag := range l.Tags {
+		b.WriteString(indent + cont + "tag: ")
+		b.WriteString(tag.Key)
+		b.WriteByte('=')
+		b.Write(errors.AppendTagValue(nil, tag.RedactedValue()))
+		b.WriteByte('\n')
+	}
+}
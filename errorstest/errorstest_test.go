@@ -0,0 +1,83 @@
+package errorstest
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+)
+
+type recorder struct {
+	testing.TB
+	failed bool
+	msg    string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...any) {
+	r.failed = true
+	r.msg = fmt.Sprintf(format, args...)
+}
+
+func load() error {
+	return errors.Wrap(io.EOF, "failed to load").AddTypes("Permanent").AddTag("key", "value")
+}
+
+func TestAssertions(t *testing.T) {
+	err := fmt.Errorf("std wrapped: %w", errors.Wrap(load(), "outer"))
+
+	tests := []struct {
+		name   string
+		assert func(tb testing.TB) bool
+		pass   bool
+	}{
+		{name: "has type", assert: func(tb testing.TB) bool { return HasType(tb, err, "Permanent") }, pass: true},
+		{name: "missing type", assert: func(tb testing.TB) bool { return HasType(tb, err, "Transient") }},
+		{name: "has tag", assert: func(tb testing.TB) bool { return HasTag(tb, err, "key", "value") }, pass: true},
+		{name: "wrong tag value", assert: func(tb testing.TB) bool { return HasTag(tb, err, "key", "other") }},
+		{name: "source", assert: func(tb testing.TB) bool { return SourceIs(tb, load(), "load") }, pass: true},
+		{name: "qualified source", assert: func(tb testing.TB) bool {
+			return SourceIs(tb, load(), "github.com/go-playground/errors/v6/errorstest.load")
+		}, pass: true},
+		{name: "wrong source", assert: func(tb testing.TB) bool { return SourceIs(tb, err, "load") }},
+		{name: "cause", assert: func(tb testing.TB) bool { return CauseIs(tb, err, io.EOF) }, pass: true},
+		{name: "wrong cause", assert: func(tb testing.TB) bool { return CauseIs(tb, err, io.ErrUnexpectedEOF) }},
+		{name: "uncomparable cause", assert: func(tb testing.TB) bool {
+			return CauseIs(tb, errors.Wrap(errors.MultiError{io.EOF}, "joined"), errors.MultiError{io.EOF})
+		}},
+		{name: "chain len", assert: func(tb testing.TB) bool { return ChainLen(tb, err, 3) }, pass: true},
+		{name: "wrong chain len", assert: func(tb testing.TB) bool { return ChainLen(tb, err, 1) }},
+		{name: "template complete", assert: func(tb testing.TB) bool {
+			return TemplatesComplete(tb, errors.WrapT(err, "loading {name}", errors.T("name", "config")))
+		}, pass: true},
+		{name: "template missing tag", assert: func(tb testing.TB) bool {
+			return TemplatesComplete(tb, errors.NewT("loading {name}", errors.T("file", "config")))
+		}},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			r := &recorder{TB: t}
+			if tc.assert(r) != tc.pass || r.failed == tc.pass {
+				t.Fatalf("want pass %t got %t: %s", tc.pass, !r.failed, r.msg)
+			}
+			if !tc.pass && !strings.Contains(r.msg, "Chain\n") {
+				t.Fatalf("want failure message to contain the error tree got %s", r.msg)
+			}
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	s := Normalize("/home/joeybloggs/go/src/store/db.go:42 C:\\src\\store\\db.go:7 ptr=0xc000123abc")
+	if want := "db.go:N db.go:N ptr=0xADDR"; s != want {
+		t.Fatalf("want %s got %s", want, s)
+	}
+}
+
+func TestGolden(t *testing.T) {
+	Golden(t, "chain", errors.Wrap(load(), "outer"))
+}
@@ -0,0 +1,75 @@
+package errorstest
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+)
+
+// UpdateEnv is the environment variable which, when set to a non-empty value, causes Golden to write the output to
+// the golden file instead of comparing against it.
+const UpdateEnv = "ERRORSTEST_UPDATE"
+
+var (
+	absPathRegex = regexp.MustCompile(`(^|[\s=("'])(?:[A-Za-z]:)?(?:[/\\][^\s/\\:]+)+[/\\]([^\s/\\:]+\.go)`)
+	lineRegex    = regexp.MustCompile(`\.go:\d+`)
+	pointerRegex = regexp.MustCompile(`0x[0-9a-fA-F]+`)
+)
+
+// Normalize replaces the machine and build specific parts of a formatted error so that it can be compared across
+// machines; absolute file paths are reduced to the file name, line numbers are replaced with N and pointer addresses
+// with 0xADDR.
+func Normalize(s string) string {
+	s = absPathRegex.ReplaceAllString(s, "${1}${2}")
+	s = lineRegex.ReplaceAllString(s, ".go:N")
+	return pointerRegex.ReplaceAllString(s, "0xADDR")
+}
+
+// Golden compares the normalized formatted error, see Normalize, against the contents of testdata/<name>.golden.
+//
+// When the ERRORSTEST_UPDATE environment variable is set the golden file is written instead.
+func Golden(tb testing.TB, name string, err error) bool {
+	tb.Helper()
+	return golden(tb, name, err.Error(), err)
+}
+
+// GoldenString compares the normalized string, see Normalize, against the contents of testdata/<name>.golden, which
+// allows comparing output from custom formatters.
+//
+// When the ERRORSTEST_UPDATE environment variable is set the golden file is written instead.
+func GoldenString(tb testing.TB, name string, s string) bool {
+	tb.Helper()
+	return golden(tb, name, s, nil)
+}
+
+func golden(tb testing.TB, name string, s string, e error) bool {
+	tb.Helper()
+	path := filepath.Join("testdata", name+".golden")
+	got := Normalize(s)
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			tb.Fatalf("failed to create golden file directory: %s", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			tb.Fatalf("failed to write golden file: %s", err)
+		}
+		return true
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		tb.Errorf("failed to read golden file, run with %s=1 to create it: %s", UpdateEnv, err)
+		return false
+	}
+	if string(want) != got {
+		if e != nil {
+			tb.Errorf("golden file %s mismatch\nwant:\n%s\ngot:\n%s\n%s", path, want, got, Tree(e))
+		} else {
+			tb.Errorf("golden file %s mismatch\nwant:\n%s\ngot:\n%s", path, want, got)
+		}
+		return false
+	}
+	return true
+}
@@ -0,0 +1,50 @@
+package errorstest
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+// Frame returns a synthetic stack frame.
+func Frame(function, file string, line int) runtimeext.Frame {
+	return runtimeext.Frame{Frame: runtime.Frame{Function: function, File: file, Line: line}}
+}
+
+// FixedSource returns a SourceProvider which always returns the supplied frame.
+func FixedSource(frame runtimeext.Frame) errors.SourceProvider {
+	return fixedSource(frame)
+}
+
+type fixedSource runtimeext.Frame
+
+func (f fixedSource) Source(int) runtimeext.Frame {
+	return runtimeext.Frame(f)
+}
+
+// SequentialSource returns a SourceProvider which returns synthetic frames for the supplied function and file with
+// line numbers incrementing from 1. It is safe for concurrent use.
+func SequentialSource(function, file string) errors.SourceProvider {
+	return &sequentialSource{function: function, file: file}
+}
+
+type sequentialSource struct {
+	function string
+	file     string
+	line     int64
+}
+
+func (s *sequentialSource) Source(int) runtimeext.Frame {
+	return Frame(s.function, s.file, int(atomic.AddInt64(&s.line, 1)))
+}
+
+// UseSource registers the SourceProvider globally until the test and its subtests complete.
+//
+// NOTE: this affects tests running in parallel, use an errors.Wrapper when that is a concern.
+func UseSource(tb testing.TB, p errors.SourceProvider) {
+	tb.Helper()
+	tb.Cleanup(errors.RegisterSourceProvider(p))
+}
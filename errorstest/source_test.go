@@ -0,0 +1,30 @@
+package errorstest
+
+import (
+	"io"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+)
+
+func TestSequentialSource(t *testing.T) {
+	t.Parallel()
+	w := &errors.Wrapper{SourceProvider: SequentialSource("example.com/store.(*DB).Load", "/src/store/db.go")}
+	err := w.Wrap(io.EOF, "failed to load").AddTag("key", "value")
+	err = err.Wrap("outer")
+
+	want := "source=example.com/store/db.go:1:Load error=EOF\n" +
+		"source=example.com/store/db.go:1:Load error=failed to load key=value\n" +
+		"source=example.com/store/db.go:2:Load error=outer"
+	if err.Error() != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, err.Error())
+	}
+}
+
+func TestUseSource(t *testing.T) {
+	UseSource(t, FixedSource(Frame("example.com/store.Load", "/src/store/db.go", 42)))
+	err := errors.New("base")
+	if want := "source=example.com/store/db.go:42:Load error=base"; err.Error() != want {
+		t.Fatalf("want %s got %s", want, err.Error())
+	}
+}
@@ -0,0 +1,92 @@
+// Package errotel records errors on OpenTelemetry spans using the attributes of errors.OTelAttributes, preserving
+// their Tags and Types.
+//
+// It is a separate module so that the errors module does not depend on OpenTelemetry.
+package errotel
+
+import (
+	"math"
+
+	"github.com/go-playground/errors/v6"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// exceptionEvent is the OpenTelemetry semantic convention name of the event recording an error.
+const exceptionEvent = "exception"
+
+// RecordError records the error as an exception event on the span, with the attributes of errors.OTelAttributes, and
+// sets the span status to Error. It does nothing if err is nil.
+//
+// The event is added directly, rather than using span.RecordError, because it would replace exception.message with
+// the full, unredacted, Error output.
+func RecordError(span trace.Span, err error, opts ...trace.EventOption) {
+	if err == nil {
+		return
+	}
+	attrs := Attributes(err)
+	span.AddEvent(exceptionEvent, append(opts, trace.WithAttributes(attrs...))...)
+
+	var msg string
+	for _, kv := range attrs {
+		if kv.Key == errors.OTelExceptionMessage {
+			msg = kv.Value.AsString()
+			break
+		}
+	}
+	span.SetStatus(codes.Error, msg)
+}
+
+// Attributes returns errors.OTelAttributes as OpenTelemetry attributes.
+func Attributes(err error) []attribute.KeyValue {
+	tags := errors.OTelAttributes(err)
+	attrs := make([]attribute.KeyValue, len(tags))
+	for i, tag := range tags {
+		attrs[i] = keyValue(tag)
+	}
+	return attrs
+}
+
+// keyValue converts the Tag to an attribute, values without an equivalent attribute type are formatted as strings
+// using errors.AppendTagValue.
+func keyValue(tag errors.Tag) attribute.KeyValue {
+	key := attribute.Key(tag.Key)
+	switch v := tag.Value.(type) {
+	case string:
+		return key.String(v)
+	case bool:
+		return key.Bool(v)
+	case int:
+		return key.Int(v)
+	case int8:
+		return key.Int64(int64(v))
+	case int16:
+		return key.Int64(int64(v))
+	case int32:
+		return key.Int64(int64(v))
+	case int64:
+		return key.Int64(v)
+	case uint8:
+		return key.Int64(int64(v))
+	case uint16:
+		return key.Int64(int64(v))
+	case uint32:
+		return key.Int64(int64(v))
+	case uint:
+		if uint64(v) <= math.MaxInt64 {
+			return key.Int64(int64(v))
+		}
+	case uint64:
+		if v <= math.MaxInt64 {
+			return key.Int64(int64(v))
+		}
+	case float32:
+		return key.Float64(float64(v))
+	case float64:
+		return key.Float64(v)
+	case []string:
+		return key.StringSlice(v)
+	}
+	return key.String(string(errors.AppendTagValue(nil, tag.Value)))
+}
@@ -0,0 +1,75 @@
+package errotel
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestRecordError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	_, span := tp.Tracer("test").Start(context.Background(), "span")
+
+	err := errors.Wrap(io.EOF, "load").AddTypes("Permanent").AddTag("id", 42).AddTag("ratio", 0.5)
+	RecordError(span, err)
+	RecordError(span, nil)
+	span.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("want 1 span got %d", len(spans))
+	}
+	s := spans[0]
+	if s.Status().Code != codes.Error || s.Status().Description != "load: EOF" {
+		t.Fatalf("want error status got %+v", s.Status())
+	}
+	if len(s.Events()) != 1 || s.Events()[0].Name != "exception" {
+		t.Fatalf("want 1 exception event got %d", len(s.Events()))
+	}
+
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range s.Events()[0].Attributes {
+		attrs[kv.Key] = kv.Value
+	}
+	tests := []struct {
+		key      attribute.Key
+		expected attribute.Value
+	}{
+		{key: errors.OTelExceptionType, expected: attribute.StringValue("*errors.errorString")},
+		{key: errors.OTelExceptionMessage, expected: attribute.StringValue("load: EOF")},
+		{key: errors.OTelErrorTypes, expected: attribute.StringSliceValue([]string{"Permanent"})},
+		{key: errors.OTelErrorTagPrefix + "id", expected: attribute.IntValue(42)},
+		{key: errors.OTelErrorTagPrefix + "ratio", expected: attribute.Float64Value(0.5)},
+	}
+	for _, tt := range tests {
+		if got := attrs[tt.key]; got != tt.expected {
+			t.Errorf("want %s=%s got %s", tt.key, tt.expected.Emit(), got.Emit())
+		}
+	}
+	if attrs[errors.OTelExceptionStacktrace].AsString() == "" {
+		t.Error("want stacktrace")
+	}
+}
+
+func TestAttributes(t *testing.T) {
+	err := errors.New("secret").AddTag("token", errors.Secret("abc")).AddTag("big", uint64(1<<63))
+	for _, kv := range Attributes(err) {
+		switch kv.Key {
+		case errors.OTelErrorTagPrefix + "token":
+			if kv.Value.AsString() == "abc" || kv.Value.Type() != attribute.STRING {
+				t.Errorf("want redacted string got %s", kv.Value.Emit())
+			}
+		case errors.OTelErrorTagPrefix + "big":
+			if kv.Value.AsString() != "9223372036854775808" {
+				t.Errorf("want overflowing integer as string got %s", kv.Value.Emit())
+			}
+		}
+	}
+}
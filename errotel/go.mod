@@ -0,0 +1,21 @@
+module github.com/go-playground/errors/v6/errotel
+
+go 1.18
+
+require (
+	github.com/go-playground/errors/v6 v6.0.0
+	go.opentelemetry.io/otel v1.14.0
+	go.opentelemetry.io/otel/sdk v1.14.0
+	go.opentelemetry.io/otel/trace v1.14.0
+)
+
+require (
+	github.com/go-logr/logr v1.2.3 // indirect
+	github.com/go-logr/stdr v1.2.2 // indirect
+	github.com/go-playground/pkg/v5 v5.21.3 // indirect
+	golang.org/x/sys v0.10.0 // indirect
+)
+
+// errotel requires errors.OTelAttributes, which is first released in v6.0.0, so is built against the errors module of
+// this repository until then; this replace must be removed before errotel is released.
+replace github.com/go-playground/errors/v6 => ../
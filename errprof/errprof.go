@@ -0,0 +1,356 @@
+// Package errprof provides an opt-in profile of where errors are created and wrapped, in the pprof format, so it can
+// be viewed using `go tool pprof` the same as a CPU profile.
+//
+//	p := errprof.Start(errprof.Options{Rate: 10})
+//	defer p.Stop()
+//	...
+//	_ = p.Write(f)
+//
+// Each sample is labelled with the types of the error, allowing the profile to be filtered by type eg.
+//
+//	go tool pprof -tagfocus type=Transient errors.pb.gz
+//
+// Types added to an existing error, using AddTypes or AddKinds, are recorded as separate samples with the op label
+// "types" at the stack they were added at, labelled with only the types the error did not already have.
+//
+// A Profile is also an http.Handler so it can be served alongside net/http/pprof.
+package errprof
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/go-playground/errors/v6"
+	"github.com/go-playground/errors/v6/internal/names"
+)
+
+const (
+	maxStackDepth = 64
+	errorsPackage = "github.com/go-playground/errors/v6"
+	profPackage   = "github.com/go-playground/errors/v6/errprof"
+)
+
+// Options configures a Profile.
+type Options struct {
+
+	// Rate records one in every Rate errors, all errors are recorded when less than or equal to 1. Sample values are
+	// scaled by the Rate to estimate the actual number of errors.
+	Rate int
+
+	// RootOnly records only the creation of new Chains, see errors.OnNew, rather than also recording each time a
+	// Link is added to a Chain. Types added to existing errors are recorded regardless, see errors.OnTypes.
+	RootOnly bool
+}
+
+// Profile records the stacks errors are created and wrapped at, see Start.
+type Profile struct {
+	opts        Options
+	start       time.Time
+	n           uint64
+	typesN      uint64
+	m           sync.Mutex
+	samples     map[sampleKey]*sample
+	unsubscribe []func()
+}
+
+type sample struct {
+	stack []uintptr
+	op    string
+	types []string
+	count int64
+}
+
+// sampleKey identifies samples with the same stack, operation and types.
+type sampleKey struct {
+	pcs   [maxStackDepth]uintptr
+	op    string
+	types string
+}
+
+// Start starts recording errors created, and unless RootOnly wrapped, from now on until Stop is called.
+func Start(opts Options) *Profile {
+	if opts.Rate < 1 {
+		opts.Rate = 1
+	}
+	p := &Profile{
+		opts:    opts,
+		start:   time.Now(),
+		samples: make(map[sampleKey]*sample),
+	}
+	p.unsubscribe = append(p.unsubscribe, errors.OnNew(func(c errors.Chain, _ *errors.Link) {
+		p.recordChain(c, "new")
+	}), errors.OnTypes(p.recordTypes))
+	if !opts.RootOnly {
+		p.unsubscribe = append(p.unsubscribe, errors.OnWrap(func(c errors.Chain, _ *errors.Link) {
+			p.recordChain(c, "wrap")
+		}))
+	}
+	return p
+}
+
+// Stop stops recording errors, the recorded samples are retained and can still be written.
+func (p *Profile) Stop() {
+	for _, unsubscribe := range p.unsubscribe {
+		unsubscribe()
+	}
+}
+
+// recordChain records the Chain labelled with the distinct types of all its Links.
+func (p *Profile) recordChain(c errors.Chain, op string) {
+	if !p.sampled(&p.n) {
+		return
+	}
+	var types []string
+	for _, l := range c {
+		for _, typ := range l.Types {
+			if !names.Contains(types, typ) {
+				types = append(types, typ)
+			}
+		}
+	}
+	p.record(op, types)
+}
+
+// recordTypes records the types added to the Link which the Chain did not already have, which are sampled separately
+// so as not to affect which errors are sampled when created or wrapped.
+func (p *Profile) recordTypes(c errors.Chain, l *errors.Link, types []string) {
+	// the types of the Chain before they were added
+	existing := l.Types[: len(l.Types)-len(types) : len(l.Types)-len(types)]
+	for _, link := range c[:len(c)-1] {
+		existing = append(existing, link.Types...)
+	}
+	var added []string
+	for _, typ := range types {
+		if !names.Contains(existing, typ) && !names.Contains(added, typ) {
+			added = append(added, typ)
+		}
+	}
+	if len(added) == 0 || !p.sampled(&p.typesN) {
+		return
+	}
+	p.record("types", added)
+}
+
+// sampled reports whether the next of the errors counted by n is sampled due to the Rate.
+func (p *Profile) sampled(n *uint64) bool {
+	return p.opts.Rate <= 1 || atomic.AddUint64(n, 1)%uint64(p.opts.Rate) == 0
+}
+
+// record adds a sample with the callers stack, the leading frames of this package and the errors package are trimmed
+// when the profile is written.
+func (p *Profile) record(op string, types []string) {
+	key := sampleKey{op: op, types: strings.Join(types, "\x00")}
+	n := runtime.Callers(3, key.pcs[:])
+
+	p.m.Lock()
+	s, ok := p.samples[key]
+	if !ok {
+		s = &sample{stack: append([]uintptr(nil), key.pcs[:n]...), op: op, types: types}
+		p.samples[key] = s
+	}
+	s.count++
+	p.m.Unlock()
+}
+
+// ServeHTTP writes the profile in the pprof format.
+func (p *Profile) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("Content-Disposition", `attachment; filename="errors"`)
+	if err := p.Write(w); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// Write writes the profile to w as a gzip compressed pprof protocol buffer.
+func (p *Profile) Write(w io.Writer) error {
+	p.m.Lock()
+	samples := make([]*sample, 0, len(p.samples))
+	for _, s := range p.samples {
+		samples = append(samples, &sample{stack: s.stack, op: s.op, types: s.types, count: s.count})
+	}
+	p.m.Unlock()
+
+	b := newBuilder()
+	b.build(samples, p.opts.Rate, p.start)
+
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(b.pb.b); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+type location struct {
+	function, file string
+	line           int
+}
+
+// builder builds the profile protocol buffer, see https://github.com/google/pprof/blob/main/proto/profile.proto.
+type builder struct {
+	pb        protobuf
+	strings   map[string]int64
+	functions map[string]uint64
+	locations map[location]uint64
+}
+
+func newBuilder() *builder {
+	b := &builder{
+		strings:   map[string]int64{"": 0},
+		functions: make(map[string]uint64),
+		locations: make(map[location]uint64),
+	}
+	return b
+}
+
+// Profile, Sample, Label, ValueType, Location, Line and Function field numbers.
+const (
+	profileSampleType    = 1
+	profileSample        = 2
+	profileLocation      = 4
+	profileFunction      = 5
+	profileStringTable   = 6
+	profileTimeNanos     = 9
+	profileDurationNanos = 10
+	profilePeriodType    = 11
+	profilePeriod        = 12
+
+	sampleLocationID = 1
+	sampleValue      = 2
+	sampleLabel      = 3
+
+	labelKey = 1
+	labelStr = 2
+
+	valueTypeType = 1
+	valueTypeUnit = 2
+
+	locationID      = 1
+	locationAddress = 3
+	locationLine    = 4
+
+	lineFunctionID = 1
+	lineLine       = 2
+
+	functionID         = 1
+	functionName       = 2
+	functionSystemName = 3
+	functionFilename   = 4
+)
+
+func (b *builder) build(samples []*sample, rate int, start time.Time) {
+	b.valueType(profileSampleType, "errors", "count")
+
+	var ids []uint64
+	for _, s := range samples {
+		ids = b.stack(ids[:0], s.stack)
+		msg := b.pb.startMessage(profileSample)
+		b.pb.packedUint64(sampleLocationID, ids)
+		b.pb.packedInt64(sampleValue, []int64{s.count * int64(rate)})
+		b.label("op", s.op)
+		for _, typ := range s.types {
+			b.label("type", typ)
+		}
+		b.pb.endMessage(msg)
+	}
+
+	b.pb.int64(profileTimeNanos, start.UnixNano())
+	b.pb.int64(profileDurationNanos, time.Since(start).Nanoseconds())
+	b.valueType(profilePeriodType, "errors", "count")
+	b.pb.int64(profilePeriod, int64(rate))
+
+	// the string table must be written in index order
+	table := make([]string, len(b.strings))
+	for s, i := range b.strings {
+		table[i] = s
+	}
+	for _, s := range table {
+		b.pb.string(profileStringTable, s)
+	}
+}
+
+// stack appends the location ids of the stack, excluding the leading frames of this profile and the errors package.
+func (b *builder) stack(ids []uint64, stack []uintptr) []uint64 {
+	frames := runtime.CallersFrames(stack)
+	trim := profPackage
+	for more := true; more; {
+		var f runtime.Frame
+		f, more = frames.Next()
+		if trim != "" {
+			pkg := names.FuncPackage(f.Function)
+			if pkg == trim || (trim == profPackage && pkg == errorsPackage) {
+				if pkg == errorsPackage {
+					trim = errorsPackage
+				}
+				continue
+			}
+			trim = ""
+		}
+		ids = append(ids, b.location(f))
+	}
+	return ids
+}
+
+func (b *builder) location(f runtime.Frame) uint64 {
+	key := location{function: f.Function, file: f.File, line: f.Line}
+	if id, ok := b.locations[key]; ok {
+		return id
+	}
+	id := uint64(len(b.locations) + 1)
+	b.locations[key] = id
+
+	fnID := b.function(f)
+	msg := b.pb.startMessage(profileLocation)
+	b.pb.uint64(locationID, id)
+	b.pb.uint64(locationAddress, uint64(f.PC))
+	line := b.pb.startMessage(locationLine)
+	b.pb.uint64(lineFunctionID, fnID)
+	b.pb.int64(lineLine, int64(f.Line))
+	b.pb.endMessage(line)
+	b.pb.endMessage(msg)
+	return id
+}
+
+func (b *builder) function(f runtime.Frame) uint64 {
+	if id, ok := b.functions[f.Function]; ok {
+		return id
+	}
+	id := uint64(len(b.functions) + 1)
+	b.functions[f.Function] = id
+
+	msg := b.pb.startMessage(profileFunction)
+	b.pb.uint64(functionID, id)
+	b.pb.int64(functionName, b.string(f.Function))
+	b.pb.int64(functionSystemName, b.string(f.Function))
+	b.pb.int64(functionFilename, b.string(f.File))
+	b.pb.endMessage(msg)
+	return id
+}
+
+func (b *builder) label(key, value string) {
+	msg := b.pb.startMessage(sampleLabel)
+	b.pb.int64(labelKey, b.string(key))
+	b.pb.int64(labelStr, b.string(value))
+	b.pb.endMessage(msg)
+}
+
+func (b *builder) valueType(field int, typ, unit string) {
+	msg := b.pb.startMessage(field)
+	b.pb.int64(valueTypeType, b.string(typ))
+	b.pb.int64(valueTypeUnit, b.string(unit))
+	b.pb.endMessage(msg)
+}
+
+func (b *builder) string(s string) int64 {
+	if i, ok := b.strings[s]; ok {
+		return i
+	}
+	i := int64(len(b.strings))
+	b.strings[s] = i
+	return i
+}
@@ -0,0 +1,220 @@
+package errprof
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+)
+
+func createError() errors.Chain {
+	return errors.New("created").AddTypes("added")
+}
+
+func wrapError(err error) errors.Chain {
+	return errors.Wrap(err, "wrapped")
+}
+
+func TestProfile(t *testing.T) {
+	p := Start(Options{})
+	for i := 0; i < 3; i++ {
+		_ = wrapError(io.EOF)
+	}
+	_ = wrapError(createError())
+	p.Stop()
+	_ = createError()
+
+	prof := decodeProfile(t, p)
+	if prof.strings[prof.sampleType] != "errors" || prof.period != 1 {
+		t.Fatalf("want errors sample type got %q with period %d", prof.strings[prof.sampleType], prof.period)
+	}
+
+	got := make(map[string]int64)
+	for _, s := range prof.samples {
+		got[s.labels["op"]+" "+s.leaf] += s.value
+	}
+	expected := map[string]int64{
+		"new github.com/go-playground/errors/v6/errprof.wrapError":     3,
+		"new github.com/go-playground/errors/v6/errprof.createError":   1,
+		"wrap github.com/go-playground/errors/v6/errprof.wrapError":    1,
+		"types github.com/go-playground/errors/v6/errprof.createError": 1,
+	}
+	if len(got) != len(expected) {
+		t.Fatalf("want samples %v got %v", expected, got)
+	}
+	for k, v := range expected {
+		if got[k] != v {
+			t.Errorf("want %s=%d got %d", k, v, got[k])
+		}
+	}
+}
+
+func TestProfileOptions(t *testing.T) {
+	p := Start(Options{Rate: 2, RootOnly: true})
+	for i := 0; i < 4; i++ {
+		_ = wrapError(errors.New("new").AddKinds(errors.Throttled))
+	}
+	p.Stop()
+
+	prof := decodeProfile(t, p)
+	if prof.period != 2 {
+		t.Fatalf("want period 2 got %d", prof.period)
+	}
+	totals := make(map[string]int64)
+	for _, s := range prof.samples {
+		if s.labels["op"] == "wrap" {
+			t.Fatal("want no wrap samples")
+		}
+		totals[s.labels["op"]] += s.value
+	}
+	if totals["new"] != 4 || totals["types"] != 4 {
+		t.Fatalf("want sampled values scaled to 4 got %v", totals)
+	}
+}
+
+func TestProfileTypes(t *testing.T) {
+	p := Start(Options{RootOnly: true})
+	_ = errors.FromPanic("boom")
+	p.Stop()
+
+	prof := decodeProfile(t, p)
+	if len(prof.samples) != 1 || prof.samples[0].labels["type"] != "Panic" {
+		t.Fatalf("want sample labelled with type got %+v", prof.samples)
+	}
+
+	rec := httptest.NewRecorder()
+	p.ServeHTTP(rec, httptest.NewRequest("GET", "/debug/pprof/errors", nil))
+	if _, err := gzip.NewReader(rec.Body); err != nil {
+		t.Fatalf("want gzipped profile got %s", err)
+	}
+}
+
+func addNotFound(c errors.Chain) errors.Chain {
+	return c.AddTypes("NotFound", "NotFound")
+}
+
+func TestProfileAddedTypes(t *testing.T) {
+	p := Start(Options{RootOnly: true})
+	c := errors.New("not found")
+	_ = addNotFound(c)
+	_ = addNotFound(addNotFound(c))
+	_ = errors.FromPanic("boom").Wrap("outer").AddTypes("Panic")
+	p.Stop()
+
+	prof := decodeProfile(t, p)
+	var found int64
+	for _, s := range prof.samples {
+		if s.labels["op"] == "types" && s.labels["type"] == "Panic" {
+			t.Fatal("want types the error already had not recorded")
+		}
+		if s.labels["type"] == "NotFound" {
+			if s.labels["op"] != "types" || s.leaf != "github.com/go-playground/errors/v6/errprof.addNotFound" {
+				t.Fatalf("want sample recorded where the type was added got %+v", s)
+			}
+			found += s.value
+		}
+	}
+	if found != 2 {
+		t.Fatalf("want type added after New recorded once per error got %d", found)
+	}
+}
+
+type decodedSample struct {
+	leaf   string
+	value  int64
+	labels map[string]string
+}
+
+type decodedProfile struct {
+	strings    []string
+	sampleType uint64
+	period     uint64
+	samples    []decodedSample
+}
+
+// decodeProfile decodes the fields of profile.proto asserted by the tests.
+func decodeProfile(t *testing.T, p *Profile) decodedProfile {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := p.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		prof      decodedProfile
+		samples   [][]field
+		locations = make(map[uint64]uint64) // location id to function id
+		functions = make(map[uint64]uint64) // function id to name string index
+	)
+	for _, f := range decodeFields(b) {
+		switch f.num {
+		case profileSampleType:
+			prof.sampleType = decodeFields(f.bytes)[0].varint
+		case profileSample:
+			samples = append(samples, decodeFields(f.bytes))
+		case profileLocation:
+			fields := decodeFields(f.bytes)
+			locations[fields[0].varint] = decodeFields(fields[len(fields)-1].bytes)[0].varint
+		case profileFunction:
+			fields := decodeFields(f.bytes)
+			functions[fields[0].varint] = fields[1].varint
+		case profileStringTable:
+			prof.strings = append(prof.strings, string(f.bytes))
+		case profilePeriod:
+			prof.period = f.varint
+		}
+	}
+
+	for _, fields := range samples {
+		s := decodedSample{labels: make(map[string]string)}
+		for _, f := range fields {
+			// only the first value of the packed location ids and values is required
+			first, _ := binary.Uvarint(f.bytes)
+			switch f.num {
+			case sampleLocationID:
+				s.leaf = prof.strings[functions[locations[first]]]
+			case sampleValue:
+				s.value = int64(first)
+			case sampleLabel:
+				label := decodeFields(f.bytes)
+				s.labels[prof.strings[label[0].varint]] = prof.strings[label[1].varint]
+			}
+		}
+		prof.samples = append(prof.samples, s)
+	}
+	return prof
+}
+
+type field struct {
+	num    int
+	varint uint64
+	bytes  []byte
+}
+
+// decodeFields decodes the varint and length delimited fields of a message, the only wire types written by Profile.
+func decodeFields(b []byte) (fields []field) {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		f := field{num: int(key >> 3)}
+		b = b[n:]
+		f.varint, n = binary.Uvarint(b)
+		b = b[n:]
+		if key&7 == 2 {
+			f.bytes, b = b[:f.varint], b[f.varint:]
+		}
+		fields = append(fields, f)
+	}
+	return
+}
@@ -0,0 +1,81 @@
+package errprof
+
+// protobuf is a minimal encoder for the subset of the protocol buffer wire format used by profile.proto.
+type protobuf struct {
+	b   []byte
+	tmp []byte
+}
+
+func (p *protobuf) varint(x uint64) {
+	for x >= 0x80 {
+		p.b = append(p.b, byte(x)|0x80)
+		x >>= 7
+	}
+	p.b = append(p.b, byte(x))
+}
+
+func (p *protobuf) tag(field, wireType int) {
+	p.varint(uint64(field)<<3 | uint64(wireType))
+}
+
+func (p *protobuf) uint64(field int, x uint64) {
+	if x == 0 {
+		return
+	}
+	p.tag(field, 0)
+	p.varint(x)
+}
+
+func (p *protobuf) int64(field int, x int64) {
+	p.uint64(field, uint64(x))
+}
+
+func (p *protobuf) packedUint64(field int, xs []uint64) {
+	if len(xs) == 0 {
+		return
+	}
+	start := p.startMessage(field)
+	for _, x := range xs {
+		p.varint(x)
+	}
+	p.endMessage(start)
+}
+
+func (p *protobuf) packedInt64(field int, xs []int64) {
+	if len(xs) == 0 {
+		return
+	}
+	start := p.startMessage(field)
+	for _, x := range xs {
+		p.varint(uint64(x))
+	}
+	p.endMessage(start)
+}
+
+func (p *protobuf) string(field int, s string) {
+	p.tag(field, 2)
+	p.varint(uint64(len(s)))
+	p.b = append(p.b, s...)
+}
+
+// startMessage begins a length delimited field, returning the offset passed to endMessage.
+func (p *protobuf) startMessage(field int) int {
+	p.tag(field, 2)
+	return len(p.b)
+}
+
+// endMessage inserts the length of the field started at start.
+func (p *protobuf) endMessage(start int) {
+	n := len(p.b) - start
+	p.tmp = p.tmp[:0]
+	for x := uint64(n); ; x >>= 7 {
+		if x < 0x80 {
+			p.tmp = append(p.tmp, byte(x))
+			break
+		}
+		p.tmp = append(p.tmp, byte(x)|0x80)
+	}
+	p.b = append(p.b, p.tmp...)
+	copy(p.b[start+len(p.tmp):], p.b[start:start+n])
+	copy(p.b[start:], p.tmp)
+}
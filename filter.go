@@ -0,0 +1,86 @@
+package errors
+
+import (
+	"regexp"
+	"runtime"
+	"strings"
+
+	"github.com/go-playground/errors/v6/internal/names"
+)
+
+var frameFilters []FrameFilter
+
+// FrameFilter reports whether the frame belongs to library code, such as a shared database client, which should be
+// skipped when choosing a Links source so that it points at the application code calling it.
+//
+// When frames are skipped the Link's Source is the first application frame and the frame the error was created or
+// wrapped in is available using Link.Origin.
+type FrameFilter func(f runtime.Frame) bool
+
+// RegisterFrameFilters adds filters used to skip library frames when choosing a Links source, see FrameFilter.
+// It is safe for concurrent use, however filters only apply to Links created afterwards so are usually registered
+// during initialization. It returns a function restoring the previously registered filters, eg. for use in tests.
+func RegisterFrameFilters(filters ...FrameFilter) (restore func()) {
+	skipMu.Lock()
+	defer skipMu.Unlock()
+	prev := frameFilters
+	frameFilters = append(prev[:len(prev):len(prev)], filters...)
+	updateSkipping()
+	return func() {
+		skipMu.Lock()
+		defer skipMu.Unlock()
+		frameFilters = prev
+		updateSkipping()
+	}
+}
+
+// FilterPackages returns a FrameFilter matching frames of functions within packages with one of the package path
+// prefixes eg. `github.com/org/platform/`.
+func FilterPackages(prefixes ...string) FrameFilter {
+	return func(f runtime.Frame) bool {
+		pkg := names.FuncPackage(f.Function)
+		for _, p := range prefixes {
+			if strings.HasPrefix(pkg, p) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterModules returns a FrameFilter matching frames of functions within the modules, including their nested
+// packages, eg. `github.com/org/dbclient`.
+func FilterModules(modules ...string) FrameFilter {
+	return func(f runtime.Frame) bool {
+		pkg := names.FuncPackage(f.Function)
+		for _, m := range modules {
+			if pkg == m || strings.HasPrefix(pkg, m) && pkg[len(m)] == '/' {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// FilterRegexp returns a FrameFilter matching frames whose fully qualified function name matches the regular
+// expression.
+func FilterRegexp(re *regexp.Regexp) FrameFilter {
+	return func(f runtime.Frame) bool {
+		return re.MatchString(f.Function)
+	}
+}
+
+// FilterVendor is a FrameFilter matching frames whose source file is within a vendor directory.
+func FilterVendor(f runtime.Frame) bool {
+	return strings.Contains(f.File, "/vendor/")
+}
+
+// filtered returns if the frame matches any of the registered FrameFilters, skipMu must be held.
+func filtered(f runtime.Frame) bool {
+	for _, filter := range frameFilters {
+		if filter(f) {
+			return true
+		}
+	}
+	return false
+}
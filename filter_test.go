@@ -0,0 +1,67 @@
+package errors
+
+import (
+	"io"
+	"regexp"
+	"runtime"
+	"strings"
+	"testing"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+func filterPlumbing(err error) Chain {
+	return Wrap(err, "exec")
+}
+
+func TestFrameFilters(t *testing.T) {
+	defer RegisterFrameFilters(FilterRegexp(regexp.MustCompile(`\.filterPlumbing$`)))()
+
+	c, source := filterPlumbing(io.EOF), runtimeext.Stack()
+
+	l := c[len(c)-1]
+	if l.Source.Function() != source.Function() || l.Source.Line() != source.Line() {
+		t.Fatalf("want application source %s:%d got %s:%d", source.Function(), source.Line(), l.Source.Function(),
+			l.Source.Line())
+	}
+	origin, ok := l.Origin()
+	if !ok || origin.Function() != "filterPlumbing" {
+		t.Fatalf("want origin filterPlumbing got %s", origin.Function())
+	}
+	if _, ok = Wrap(io.EOF, "unfiltered").current().Origin(); ok {
+		t.Fatal("want no origin when no frames are filtered")
+	}
+
+	for name, fn := range map[string]ErrorFormatFn{"logfmt": LogfmtFormat, "json": JSONFormat, "tree": TreeFormat} {
+		if s := fn(c); !strings.Contains(s, ":filterPlumbing") {
+			t.Errorf("%s: want origin got %s", name, s)
+		}
+	}
+}
+
+func TestFrameFilterFuncs(t *testing.T) {
+	frame := func(fn, file string) runtime.Frame {
+		return runtime.Frame{Function: fn, File: file}
+	}
+	tests := []struct {
+		name     string
+		filter   FrameFilter
+		frame    runtime.Frame
+		expected bool
+	}{
+		{name: "package prefix", filter: FilterPackages("github.com/org/platform/"), frame: frame("github.com/org/platform/db.(*Client).exec", ""), expected: true},
+		{name: "package prefix mismatch", filter: FilterPackages("github.com/org/platform/"), frame: frame("github.com/org/app.Handler", ""), expected: false},
+		{name: "module", filter: FilterModules("github.com/org/db"), frame: frame("github.com/org/db.exec", ""), expected: true},
+		{name: "module nested", filter: FilterModules("github.com/org/db"), frame: frame("github.com/org/db/internal.exec", ""), expected: true},
+		{name: "module sibling", filter: FilterModules("github.com/org/db"), frame: frame("github.com/org/dbx.exec", ""), expected: false},
+		{name: "regexp", filter: FilterRegexp(regexp.MustCompile(`\.exec$`)), frame: frame("github.com/org/db.exec", ""), expected: true},
+		{name: "vendor", filter: FilterVendor, frame: frame("github.com/org/db.exec", "/src/app/vendor/github.com/org/db/db.go"), expected: true},
+		{name: "not vendor", filter: FilterVendor, frame: frame("github.com/org/db.exec", "/src/db/db.go"), expected: false},
+	}
+
+	for _, tc := range tests {
+		if actual := tc.filter(tc.frame); actual != tc.expected {
+			t.Errorf("%s: want %t got %t", tc.name, tc.expected, actual)
+		}
+	}
+}
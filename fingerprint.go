@@ -0,0 +1,118 @@
+package errors
+
+import (
+	"hash/fnv"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/errors/v6/internal/names"
+)
+
+var fingerprintOpts FingerprintOptions
+
+// FingerprintOptions controls which information is used when calculating an errors fingerprint.
+type FingerprintOptions struct {
+
+	// IncludeLine includes the line number of each Links source, by default only the function and file are used
+	// so that the fingerprint remains stable when unrelated lines are added or removed.
+	IncludeLine bool
+
+	// IgnoreVendor excludes the source of Links whose source file is within a vendor directory, their Types are still
+	// included.
+	IgnoreVendor bool
+
+	// IgnorePackages excludes the source of Links whose source function is within a package with one of the provided
+	// package path prefixes eg. library packages which are shared by many unrelated callers. Their Types are still
+	// included.
+	IgnorePackages []string
+}
+
+// RegisterFingerprintOptions sets the options used by the Fingerprint function.
+func RegisterFingerprintOptions(opts FingerprintOptions) {
+	fingerprintOpts = opts
+}
+
+// Fingerprint returns a stable hash of the error which can be used to group occurrences of the same error.
+//
+// The fingerprint is built from the source function and file of every Link, the Types and template, see NewT, of every
+// Link and the Go type of the root cause; Tag values and messages are excluded as they often contain dynamic
+// information.
+func Fingerprint(err error) string {
+	return fingerprintOpts.Fingerprint(err)
+}
+
+// Fingerprint returns a stable hash of the error using the options, see Fingerprint.
+func (o FingerprintOptions) Fingerprint(err error) string {
+	if err == nil {
+		return ""
+	}
+	h := fnv.New64a()
+	b := make([]byte, 0, 256)
+	cause := err
+
+	for cause != nil {
+		switch t := cause.(type) {
+		case Chain:
+			for _, l := range t {
+				b = o.appendLink(b, l)
+			}
+			cause = t[0].Err
+			continue
+		case unwrap:
+			if inner := t.Unwrap(); inner != nil {
+				cause = inner
+				continue
+			}
+		}
+		break
+	}
+	if cause != nil {
+		b = append(b, reflect.TypeOf(cause).String()...)
+	}
+	_, _ = h.Write(b)
+	return strconv.FormatUint(h.Sum64(), 16)
+}
+
+// appendLink appends the Links source, Types and template. Only the source of ignored Links is excluded so that errors
+// classified differently within them still have different fingerprints.
+func (o FingerprintOptions) appendLink(b []byte, l *Link) []byte {
+	ignored := o.ignored(l)
+	if ignored && len(l.Types) == 0 && l.extension().template == "" {
+		return b
+	}
+	if !ignored {
+		b = append(b, l.Source.Frame.Function...)
+		b = append(b, 0)
+		b = append(b, l.Source.File()...)
+		if o.IncludeLine {
+			b = append(b, ':')
+			b = strconv.AppendInt(b, int64(l.Source.Line()), 10)
+		}
+	}
+	for _, typ := range l.Types {
+		b = append(b, 0)
+		b = append(b, typ...)
+	}
+	if template := l.extension().template; template != "" {
+		b = append(b, 0)
+		b = append(b, template...)
+	}
+	return append(b, '\n')
+}
+
+func (o FingerprintOptions) ignored(l *Link) bool {
+	if o.IgnoreVendor && strings.Contains(l.Source.Frame.File, "/vendor/") {
+		return true
+	}
+	if len(o.IgnorePackages) == 0 {
+		return false
+	}
+	pkg := names.FuncPackage(l.Source.Frame.Function)
+	for _, p := range o.IgnorePackages {
+		if strings.HasPrefix(pkg, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,38 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	newTypedErr := func(id int, typ string) error {
+		return Wrapf(io.EOF, "failed to find user %d", id).AddTag("id", id).AddTypes(typ)
+	}
+	newErr := func(id int) error {
+		return newTypedErr(id, "NotFound")
+	}
+	other := Wrap(io.EOF, "failed to find user 1").AddTypes("NotFound")
+
+	if Fingerprint(newErr(1)) != Fingerprint(newErr(2)) {
+		t.Fatalf("want equal fingerprints for the same error with different tags and messages")
+	}
+	if Fingerprint(newErr(1)) == Fingerprint(other) {
+		t.Fatalf("want different fingerprints for errors created at different sources")
+	}
+	if Fingerprint(newErr(1)) == Fingerprint(newTypedErr(1, "PermissionDenied")) {
+		t.Fatalf("want different fingerprints for errors with different types")
+	}
+	if Fingerprint(newErr(1)) != Fingerprint(fmt.Errorf("std wrapped: %w", newErr(1))) {
+		t.Fatalf("want std wrapped error to have the same fingerprint")
+	}
+
+	opts := FingerprintOptions{IgnorePackages: []string{"github.com/go-playground/errors/v6"}}
+	if opts.Fingerprint(newErr(1)) != opts.Fingerprint(New("other").AddTypes("NotFound")) {
+		t.Fatalf("want ignored package sources to be excluded from the fingerprint")
+	}
+	if opts.Fingerprint(newErr(1)) == opts.Fingerprint(newTypedErr(1, "PermissionDenied")) {
+		t.Fatalf("want the types of ignored Links included in the fingerprint")
+	}
+}
@@ -0,0 +1,496 @@
+package errors
+
+import (
+	"encoding/json"
+	stderrors "errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	unsafeext "github.com/go-playground/pkg/v5/unsafe"
+)
+
+const (
+	ansiReset  = "\x1b[0m"
+	ansiBold   = "\x1b[1m"
+	ansiDim    = "\x1b[2m"
+	ansiRed    = "\x1b[31m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// Format formats the error using the provided ErrorFormatFn, allowing the format to be selected per call.
+//
+// The first Chain found in the error, see As, is formatted so that Chains wrapped by other error types are formatted
+// as Chains, with the text of the wrapping errors kept as an outer Link without a source. Errors not containing a
+// Chain are formatted as a Chain with a single Link without a source, so that the output is always in the requested
+// format. An empty string is returned for a nil error.
+func Format(err error, fn ErrorFormatFn) string {
+	if err == nil {
+		return ""
+	}
+	return fn(asFormatChain(err))
+}
+
+// asFormatChain returns the error as a Chain for formatting, see Format.
+func asFormatChain(err error) Chain {
+	if c, ok := err.(Chain); ok && len(c) > 0 {
+		return c
+	}
+	var c Chain
+	if !stderrors.As(err, &c) || len(c) == 0 {
+		return Chain{&Link{Err: err}}
+	}
+	// the wrapping errors text usually ends with the Chains, which is trimmed to avoid repeating it
+	outer := err.Error()
+	if inner := c.Error(); strings.HasSuffix(outer, inner) {
+		outer = strings.TrimSuffix(outer[:len(outer)-len(inner)], ": ")
+	}
+	if outer == "" {
+		return c
+	}
+	return append(c[:len(c):len(c)], &Link{Prefix: outer})
+}
+
+// LogfmtFormat formats each Link on its own line as strict logfmt, quoting and escaping values where required.
+// The origin, see Link.Origin, captured metadata, see Capture, and default Tags, see RegisterDefaultTags, are
+// included when present.
+//
+//	source=github.com/org/module/db.go:42:Load error="failed to load: EOF" key="a value" types=Permanent,io
+func LogfmtFormat(c Chain) string {
+	if len(c) == 0 {
+		return ""
+	}
+	b := make([]byte, 0, len(c)*192)
+	for _, l := range c {
+		b = appendLogfmtLink(b, l)
+		b = append(b, '\n')
+	}
+	return unsafeext.BytesToString(b[:len(b)-1])
+}
+
+// JSONFormat formats the Chain as a single line JSON object containing the compact error message and the Links,
+// from the root Link first. The default Tags, see RegisterDefaultTags, origin, see Link.Origin, and captured metadata,
+// see Capture, are included when present.
+//
+//	{"error":"failed to load: EOF","links":[{"source":"github.com/org/module/db.go:42:Load","message":"EOF"}, ...]}
+func JSONFormat(c Chain) string {
+	b := make([]byte, 0, len(c)*192)
+	return unsafeext.BytesToString(appendJSON(b, c))
+}
+
+// CompactFormat formats the Chain as messages only, from the outermost Link first.
+//
+//	failed to handle request: failed to load: EOF
+func CompactFormat(c Chain) string {
+	return unsafeext.BytesToString(appendCompact(make([]byte, 0, len(c)*32), c))
+}
+
+// TreeFormat formats the Chain as a human-readable indented tree, from the outermost Link first. The origin, see
+// Link.Origin, and the captured elapsed time, goroutine and labels, see Capture, are included after the source when
+// present.
+//
+//	failed to handle request (github.com/org/module/handler.go:20:ServeHTTP)
+//	└─ failed to load (github.com/org/module/db.go:42:Load) [Permanent] key=value
+//	   └─ EOF (github.com/org/module/db.go:42:Load)
+func TreeFormat(c Chain) string {
+	return unsafeext.BytesToString(appendTree(make([]byte, 0, len(c)*128), c, false))
+}
+
+// ColorTreeFormat formats the Chain the same as TreeFormat using ANSI colours for terminal output.
+func ColorTreeFormat(c Chain) string {
+	return unsafeext.BytesToString(appendTree(make([]byte, 0, len(c)*160), c, true))
+}
+
+func appendLogfmtLink(b []byte, l *Link) []byte {
+	b = append(b, "source="...)
+	start := len(b)
+	b = quoteLogfmt(AppendSource(b, l.Source, sourcePathPolicy), start)
+
+	if origin := l.extension().origin; origin != nil {
+		b = append(b, " origin="...)
+		start = len(b)
+		b = quoteLogfmt(AppendSource(b, *origin, sourcePathPolicy), start)
+	}
+
+	b = append(b, " error="...)
+	start = len(b)
+	b = quoteLogfmt(l.appendMessage(b), start)
+
+	if meta := l.extension().meta; meta != nil {
+		b = appendLogfmtMeta(b, meta)
+	}
+
+	for _, tag := range defaultTags {
+		b = appendLogfmtTag(b, tag)
+	}
+
+	for _, tag := range l.Tags {
+		b = appendLogfmtTag(b, tag)
+	}
+
+	if len(l.Types) > 0 {
+		b = append(b, " types="...)
+		start = len(b)
+		for i, t := range l.Types {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			// escape the separator so types containing it can be unambiguously split
+			for j := 0; j < len(t); j++ {
+				if t[j] == ',' || t[j] == '\\' {
+					b = append(b, '\\')
+				}
+				b = append(b, t[j])
+			}
+		}
+		b = quoteLogfmt(b, start)
+	}
+	return b
+}
+
+func appendLogfmtTag(b []byte, tag Tag) []byte {
+	b = append(b, ' ')
+	b = appendLogfmtKey(b, tag.Key)
+	b = append(b, '=')
+	start := len(b)
+	return quoteLogfmt(AppendTagValue(b, tag.RedactedValue()), start)
+}
+
+// appendLogfmtMeta appends the captured metadata, see Capture, with labels prefixed by `label.`.
+func appendLogfmtMeta(b []byte, m *linkMeta) []byte {
+	if !m.time.IsZero() {
+		b = append(b, " time="...)
+		b = m.time.AppendFormat(b, time.RFC3339Nano)
+		b = append(b, " elapsed="...)
+		b = append(b, m.time.Sub(m.root).String()...)
+	}
+	if m.goroutine != 0 {
+		b = append(b, " goroutine="...)
+		b = strconv.AppendUint(b, m.goroutine, 10)
+	}
+	for _, label := range m.labels {
+		b = append(b, " label."...)
+		b = appendLogfmtKey(b, label.Key)
+		b = append(b, '=')
+		start := len(b)
+		b = quoteLogfmt(AppendTagValue(b, label.Value), start)
+	}
+	return b
+}
+
+// appendLogfmtKey appends the key replacing any characters not permitted in a logfmt key with an underscore.
+func appendLogfmtKey(b []byte, key string) []byte {
+	if key == "" {
+		return append(b, '_')
+	}
+	for i := 0; i < len(key); i++ {
+		if c := key[i]; c <= ' ' || c == '=' || c == '"' || c == 0x7f {
+			b = append(b, '_')
+		} else {
+			b = append(b, c)
+		}
+	}
+	return b
+}
+
+// quoteLogfmt quotes the value appended from start if it is empty or contains characters requiring quoting.
+func quoteLogfmt(b []byte, start int) []byte {
+	v := b[start:]
+	quote := len(v) == 0 || !utf8.Valid(v)
+	for i := 0; i < len(v) && !quote; i++ {
+		quote = v[i] <= ' ' || v[i] == '=' || v[i] == '"' || v[i] == 0x7f
+	}
+	if !quote {
+		return b
+	}
+	return strconv.AppendQuote(b[:start], string(v))
+}
+
+func appendJSON(b []byte, c Chain) []byte {
+	b = append(b, `{"error":"`...)
+	start := len(b)
+	b = quoteJSON(appendCompact(b, c), start)
+
+	if len(defaultTags) > 0 {
+		b = append(b, `,"tags":`...)
+		b = appendJSONTags(b, defaultTags)
+	}
+
+	b = append(b, `,"links":[`...)
+	for i, l := range c {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, `{"source":"`...)
+		start = len(b)
+		b = quoteJSON(AppendSource(b, l.Source, sourcePathPolicy), start)
+
+		if origin := l.extension().origin; origin != nil {
+			b = append(b, `,"origin":"`...)
+			start = len(b)
+			b = quoteJSON(AppendSource(b, *origin, sourcePathPolicy), start)
+		}
+
+		b = append(b, `,"message":"`...)
+		start = len(b)
+		b = quoteJSON(l.appendMessage(b), start)
+
+		if meta := l.extension().meta; meta != nil {
+			b = appendJSONMeta(b, meta)
+		}
+
+		if len(l.Types) > 0 {
+			b = append(b, `,"types":[`...)
+			for j, t := range l.Types {
+				if j > 0 {
+					b = append(b, ',')
+				}
+				b = appendJSONString(b, t)
+			}
+			b = append(b, ']')
+		}
+		if len(l.Tags) > 0 {
+			b = append(b, `,"tags":`...)
+			b = appendJSONTags(b, l.Tags)
+		}
+		b = append(b, '}')
+	}
+	return append(b, "]}"...)
+}
+
+// appendJSONTags appends the Tags as a JSON object.
+func appendJSONTags(b []byte, tags []Tag) []byte {
+	b = append(b, '{')
+	for i, tag := range tags {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = appendJSONString(b, tag.Key)
+		b = append(b, ':')
+		b = appendJSONValue(b, tag.RedactedValue())
+	}
+	return append(b, '}')
+}
+
+// appendJSONMeta appends the captured metadata, see Capture, as fields of the Link object.
+func appendJSONMeta(b []byte, m *linkMeta) []byte {
+	if !m.time.IsZero() {
+		b = append(b, `,"time":"`...)
+		b = m.time.AppendFormat(b, time.RFC3339Nano)
+		b = append(b, `","elapsed":"`...)
+		b = append(b, m.time.Sub(m.root).String()...)
+		b = append(b, '"')
+	}
+	if m.goroutine != 0 {
+		b = append(b, `,"goroutine":`...)
+		b = strconv.AppendUint(b, m.goroutine, 10)
+	}
+	if len(m.labels) > 0 {
+		b = append(b, `,"labels":`...)
+		b = appendJSONTags(b, m.labels)
+	}
+	return b
+}
+
+// appendJSONValue appends the value as JSON using strconv where possible to avoid allocations.
+func appendJSONValue(b []byte, v any) []byte {
+	switch t := v.(type) {
+	case nil:
+		return append(b, "null"...)
+	case string:
+		return appendJSONString(b, t)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return AppendTagValue(b, t)
+	case float32:
+		if math.IsNaN(float64(t)) || math.IsInf(float64(t), 0) {
+			return appendJSONString(b, strconv.FormatFloat(float64(t), 'g', -1, 32))
+		}
+		return AppendTagValue(b, t)
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return appendJSONString(b, strconv.FormatFloat(t, 'g', -1, 64))
+		}
+		return AppendTagValue(b, t)
+	case SecretValue:
+		return appendJSONString(b, t.String())
+	}
+	if !hasTagValueAppender(v) {
+		if j, err := json.Marshal(v); err == nil {
+			return append(b, j...)
+		}
+	}
+	b = append(b, '"')
+	start := len(b)
+	return quoteJSON(AppendTagValue(b, v), start)
+}
+
+// quoteJSON completes the JSON string whose opening quote precedes start, escaping the value appended from start only
+// if required.
+func quoteJSON(b []byte, start int) []byte {
+	v := b[start:]
+	for i := 0; i < len(v); {
+		if c := v[i]; c < utf8.RuneSelf {
+			if c < ' ' || c == '"' || c == '\\' {
+				return appendJSONString(b[:start-1], string(v))
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRune(v[i:])
+		if r == utf8.RuneError && size == 1 {
+			return appendJSONString(b[:start-1], string(v))
+		}
+		i += size
+	}
+	return append(b, '"')
+}
+
+// appendJSONString appends the string as a quoted and escaped JSON string.
+func appendJSONString(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				b = append(b, '\\', c)
+			case c == '\n':
+				b = append(b, '\\', 'n')
+			case c == '\r':
+				b = append(b, '\\', 'r')
+			case c == '\t':
+				b = append(b, '\\', 't')
+			case c < ' ':
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			default:
+				b = append(b, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, "\ufffd"...)
+		} else {
+			b = append(b, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(b, '"')
+}
+
+func appendCompact(b []byte, c Chain) []byte {
+	begin := len(b)
+	for i := len(c) - 1; i >= 0; i-- {
+		mark := len(b)
+		if mark > begin {
+			b = append(b, ": "...)
+		}
+		start := len(b)
+		b = c[i].appendMessage(b)
+		if len(b) == start {
+			b = b[:mark]
+		}
+	}
+	return b
+}
+
+func appendTree(b []byte, c Chain, color bool) []byte {
+	for i := len(c) - 1; i >= 0; i-- {
+		l := c[i]
+		depth := len(c) - 1 - i
+		if depth > 0 {
+			b = append(b, '\n')
+			for j := 1; j < depth; j++ {
+				b = append(b, "   "...)
+			}
+			b = append(b, "└─ "...)
+		}
+
+		if color {
+			b = append(b, ansiBold...)
+			if i == 0 {
+				b = append(b, ansiRed...)
+			}
+		}
+		b = l.appendMessage(b)
+		if color {
+			b = append(b, ansiReset...)
+			b = append(b, ansiDim...)
+		}
+		b = append(b, " ("...)
+		b = AppendSource(b, l.Source, sourcePathPolicy)
+		if origin := l.extension().origin; origin != nil {
+			b = append(b, " via "...)
+			b = AppendSource(b, *origin, sourcePathPolicy)
+		}
+		b = append(b, ')')
+		if meta := l.extension().meta; meta != nil {
+			b = appendTreeMeta(b, meta)
+		}
+		if color {
+			b = append(b, ansiReset...)
+		}
+
+		if len(l.Types) > 0 {
+			b = append(b, ' ')
+			if color {
+				b = append(b, ansiYellow...)
+			}
+			b = append(b, '[')
+			for j, t := range l.Types {
+				if j > 0 {
+					b = append(b, ", "...)
+				}
+				b = append(b, t...)
+			}
+			b = append(b, ']')
+			if color {
+				b = append(b, ansiReset...)
+			}
+		}
+
+		for _, tag := range l.Tags {
+			b = append(b, ' ')
+			if color {
+				b = append(b, ansiCyan...)
+			}
+			b = append(b, tag.Key...)
+			b = append(b, '=')
+			b = AppendTagValue(b, tag.RedactedValue())
+			if color {
+				b = append(b, ansiReset...)
+			}
+		}
+	}
+	return b
+}
+
+// appendTreeMeta appends the captured metadata, see Capture, eg. ` +1.5ms goroutine 18 {request=42}`.
+func appendTreeMeta(b []byte, m *linkMeta) []byte {
+	if !m.time.IsZero() {
+		b = append(b, " +"...)
+		b = append(b, m.time.Sub(m.root).String()...)
+	}
+	if m.goroutine != 0 {
+		b = append(b, " goroutine "...)
+		b = strconv.AppendUint(b, m.goroutine, 10)
+	}
+	if len(m.labels) > 0 {
+		b = append(b, " {"...)
+		for i, label := range m.labels {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = append(b, label.Key...)
+			b = append(b, '=')
+			b = AppendTagValue(b, label.Value)
+		}
+		b = append(b, '}')
+	}
+	return b
+}
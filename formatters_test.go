@@ -0,0 +1,83 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestFormatters(t *testing.T) {
+	w := &Wrapper{SourceProvider: &fixedSource{}}
+	err := w.Wrap(io.EOF, "failed to load").
+		AddTags(T("key", "a value"), T("count", 2), T("k=y", `quote"d`)).
+		AddTypes("Permanent", "a,b").
+		Wrap("outer")
+
+	tests := []struct {
+		name string
+		fn   ErrorFormatFn
+		want string
+	}{
+		{
+			name: "logfmt",
+			fn:   LogfmtFormat,
+			want: "source=example.com/store/db.go:1:Load error=EOF\n" +
+				`source=example.com/store/db.go:1:Load error="failed to load" key="a value" count=2 k_y="quote\"d" types=Permanent,a\,b` + "\n" +
+				"source=example.com/store/db.go:2:Load error=outer",
+		},
+		{
+			name: "json",
+			fn:   JSONFormat,
+			want: `{"error":"outer: failed to load: EOF","links":[` +
+				`{"source":"example.com/store/db.go:1:Load","message":"EOF"},` +
+				`{"source":"example.com/store/db.go:1:Load","message":"failed to load","types":["Permanent","a,b"],"tags":{"key":"a value","count":2,"k=y":"quote\"d"}},` +
+				`{"source":"example.com/store/db.go:2:Load","message":"outer"}]}`,
+		},
+		{
+			name: "compact",
+			fn:   CompactFormat,
+			want: "outer: failed to load: EOF",
+		},
+		{
+			name: "tree",
+			fn:   TreeFormat,
+			want: "outer (example.com/store/db.go:2:Load)\n" +
+				`└─ failed to load (example.com/store/db.go:1:Load) [Permanent, a,b] key=a value count=2 k=y=quote"d` + "\n" +
+				"   └─ EOF (example.com/store/db.go:1:Load)",
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := Format(err, tc.fn); got != tc.want {
+				t.Fatalf("want:\n%s\ngot:\n%s", tc.want, got)
+			}
+		})
+	}
+
+	if !json.Valid([]byte(JSONFormat(err))) {
+		t.Fatalf("want valid JSON got %s", JSONFormat(err))
+	}
+	if got := Format(io.EOF, JSONFormat); !json.Valid([]byte(got)) || !strings.Contains(got, `"error":"EOF"`) {
+		t.Fatalf("want non Chain error formatted as JSON got %s", got)
+	}
+	if got := Format(io.EOF, CompactFormat); got != "EOF" {
+		t.Fatalf("want EOF got %s", got)
+	}
+	if got, want := Format(fmt.Errorf("handler ctx: %w", err), CompactFormat), "handler ctx: outer: failed to load: EOF"; got != want {
+		t.Fatalf("want wrapped Chain formatted %s got %s", want, got)
+	}
+	if got := Format(fmt.Errorf("handler ctx: %w", err), TreeFormat); !strings.HasPrefix(got, "handler ctx (:0)\n└─ outer ") {
+		t.Fatalf("want wrapping error text as the outer Link got %s", got)
+	}
+	for name, fn := range map[string]ErrorFormatFn{"logfmt": LogfmtFormat, "compact": CompactFormat, "tree": TreeFormat} {
+		if got := fn(Chain{}); got != "" {
+			t.Fatalf("want empty %s string for empty Chain got %s", name, got)
+		}
+	}
+	if got := Format(nil, JSONFormat); got != "" {
+		t.Fatalf("want empty string for nil error got %s", got)
+	}
+}
@@ -1,4 +1,4 @@
-module github.com/go-playground/errors/v5
+module github.com/go-playground/errors/v6
 
 go 1.18
 
@@ -6,3 +6,5 @@ require (
 	github.com/aws/aws-sdk-go v1.45.27
 	github.com/go-playground/pkg/v5 v5.21.3
 )
+
+require github.com/jmespath/go-jmespath v0.4.0 // indirect
@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"context"
+	"sync"
+)
+
+const goroutinePrefix = "goroutine"
+
+// MultiError contains multiple errors, such as those returned by the goroutines of a Group.
+type MultiError []error
+
+// Error returns all the errors separated by a newline.
+func (m MultiError) Error() string {
+	b := make([]byte, 0, len(m)*192)
+	for _, err := range m {
+		b = append(b, err.Error()...)
+		b = append(b, '\n')
+	}
+	if len(b) == 0 {
+		return ""
+	}
+	return string(b[:len(b)-1])
+}
+
+// Unwrap returns the contained errors.
+func (m MultiError) Unwrap() []error {
+	return m
+}
+
+// Go runs fn in a new goroutine and returns a channel which receives its result.
+//
+// A non-nil error, or a recovered panic, is returned as a Chain with an additional Link whose source is the caller
+// of Go.
+func Go(fn func() error) <-chan error {
+	l := std.newLink(nil, goroutinePrefix, 2)
+	ch := make(chan error, 1)
+	go func() {
+		ch <- runSpawned(fn, l)
+	}()
+	return ch
+}
+
+// Group is a collection of goroutines working on subtasks of a common task, similar to errgroup.Group.
+//
+// A zero Group is valid and does not cancel on error.
+type Group struct {
+	wg     sync.WaitGroup
+	m      sync.Mutex
+	errs   MultiError
+	cancel context.CancelFunc
+}
+
+// GroupWithContext returns a new Group and an associated Context derived from ctx.
+//
+// The derived Context is canceled the first time a function passed to Go returns a non-nil error or panics, or the
+// first time Wait returns, whichever occurs first.
+func GroupWithContext(ctx context.Context) (*Group, context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	return &Group{cancel: cancel}, ctx
+}
+
+// Go calls the given function in a new goroutine.
+//
+// A non-nil error, or a recovered panic, is recorded as a Chain with an additional Link whose source is the caller
+// of Go.
+func (g *Group) Go(fn func() error) {
+	l := std.newLink(nil, goroutinePrefix, 2)
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		if err := runSpawned(fn, l); err != nil {
+			g.m.Lock()
+			g.errs = append(g.errs, err)
+			g.m.Unlock()
+			if g.cancel != nil {
+				g.cancel()
+			}
+		}
+	}()
+}
+
+// Wait blocks until all function calls from the Go method have returned and then returns a Chain containing a
+// MultiError of all failures, or nil if none failed.
+func (g *Group) Wait() error {
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel()
+	}
+	if len(g.errs) == 0 {
+		return nil
+	}
+	return std.wrap(g.errs, "", 3)
+}
+
+// runSpawned runs fn wrapping any error, or recovered panic, with the Link created by the caller of Go.
+func runSpawned(fn func() error, l *Link) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = wrapLink(nil, fromPanic(r), l)
+		}
+	}()
+	if err = fn(); err != nil {
+		err = wrapLink(nil, err, l)
+	}
+	return
+}
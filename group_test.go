@@ -0,0 +1,73 @@
+package errors
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+func TestGo(t *testing.T) {
+	fn := func() error {
+		return Wrap(io.EOF, "prefix").AddTypes("Permanent")
+	}
+	err, source := <-Go(fn), runtimeext.Stack()
+	c, ok := err.(Chain)
+	if !ok {
+		t.Fatalf("want Chain got %T", err)
+	}
+	link := c.current()
+	if link.Prefix != goroutinePrefix || link.Source.Function() != "TestGo" || link.Source.Line() != source.Line() {
+		t.Fatalf("want spawn site TestGo:%d got %s:%d", source.Line(), link.Source.Function(), link.Source.Line())
+	}
+	if !HasType(err, "Permanent") || !Is(err, io.EOF) {
+		t.Fatalf("want original error to be wrapped got %s", err)
+	}
+
+	if err = <-Go(func() error { return nil }); err != nil {
+		t.Fatalf("want nil got %s", err)
+	}
+
+	err = <-Go(func() error { panic("boom") })
+	if !HasType(err, panicType) {
+		t.Fatalf("want panic to be recovered got %s", err)
+	}
+}
+
+func TestGroup(t *testing.T) {
+	g, ctx := GroupWithContext(context.Background())
+	g.Go(func() error {
+		return io.EOF
+	})
+	g.Go(func() error {
+		<-ctx.Done()
+		return Wrap(ctx.Err(), "canceled").AddTag("key", "value")
+	})
+	g.Go(func() error {
+		return nil
+	})
+	err := g.Wait()
+	if err == nil {
+		t.Fatal("want error got nil")
+	}
+	multi, ok := Cause(err).(MultiError)
+	if !ok || len(multi) != 2 {
+		t.Fatalf("want MultiError with 2 errors got %#v", Cause(err))
+	}
+	for _, e := range multi {
+		link := e.(Chain).current()
+		if link.Source.Function() != "TestGroup" {
+			t.Fatalf("want spawn site TestGroup got %s", link.Source.Function())
+		}
+	}
+	if LookupTag(err, "key") != "value" {
+		t.Fatalf("want tag from child error got %s", err)
+	}
+
+	var zero Group
+	zero.Go(func() error { return nil })
+	if err = zero.Wait(); err != nil {
+		t.Fatalf("want nil got %s", err)
+	}
+}
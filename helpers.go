@@ -4,3 +4,37 @@ package errors
 // add it to the supplied *Link error; this can be used independently or by registering using errors.RegisterHelper(...),
 // which will run the registered helper every time errors.Wrap(...) is called.
 type Helper func(Chain, error) bool
+
+// buildingExt is the extension of Links without one while they are being built by the helpers, avoiding allocating
+// one, it must not be modified.
+var buildingExt = &linkExt{building: true}
+
+// RunHelpers runs all registered helpers, in the order they were added, against the supplied error until one
+// signals a match; any extracted Type and Tag information is added to the Chain's current Link in place.
+//
+// This is called automatically when wrapping a non Chain error but is exported for helpers that need to classify
+// nested errors, such as an original error contained within a third party error type. As the Link is modified in
+// place the Chain should be newly created and not yet shared.
+func RunHelpers(c Chain, err error) {
+	l := c.current()
+	if !l.extension().building {
+		if l.ext == nil {
+			l.ext = buildingExt
+		} else {
+			l.extend().building = true
+		}
+		defer func() {
+			if l.ext == buildingExt {
+				l.ext = nil
+			} else {
+				// the extension was copied for this Link while building so is not shared
+				l.ext.building = false
+			}
+		}()
+	}
+	for _, h := range helpers {
+		if !h(c, err) {
+			break
+		}
+	}
+}
@@ -2,34 +2,152 @@ package awserrors
 
 import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
-	"github.com/go-playground/errors/v5"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/go-playground/errors/v6"
+	"github.com/go-playground/errors/v6/internal/names"
+	"github.com/go-playground/errors/v6/kinds"
 )
 
 const (
+	permanent = "Permanent"
 	transient = "Transient"
+	throttled = "Throttled"
+
+	// batchedErrorsCode is the code used by the SDK for batches of errors, see awserr.NewBatchError.
+	batchedErrorsCode = "BatchedErrors"
 )
 
+// throttleCodes are the throttling codes which are always classified as Throttled, in addition to those
+// recognised by request.IsErrorThrottle.
+var throttleCodes = map[string]struct{}{
+	"Throttling":                             {},
+	"ThrottlingException":                    {},
+	"RequestLimitExceeded":                   {},
+	"ProvisionedThroughputExceededException": {},
+}
+
 func init() {
 	errors.RegisterHelper(AWSErrors)
 }
 
-// AWSErrors helps classify io related errors
+// AWSErrors helps classify aws related errors.
+//
+// Throttling errors are classified as Transient and Throttled and errors retryable according to
+// request.IsErrorRetryable as Transient. The remaining request failures are classified by their status code, 5xx as
+// Transient and 4xx as Permanent, and all other errors as Permanent. The well-known Kind of the status code, see the
+// kinds package, is also added.
+//
+// The original error(s) are run through all registered helpers and their Types and Tags are added to the Chain.
 func AWSErrors(c errors.Chain, err error) (cont bool) {
-	switch e := err.(type) {
-	case awserr.BatchedErrors:
-		_ = c.AddTypes(transient, "Batch")
-		return
-
-	case awserr.RequestFailure:
-		_ = c.AddTypes(transient, "Request").AddTags(
-			errors.T("status_code", e.StatusCode()),
-			errors.T("request_id", e.RequestID()),
+	e, ok := err.(awserr.Error)
+	if !ok {
+		return true
+	}
+
+	var origErrs []error
+	if be, ok := err.(awserr.BatchedErrors); ok {
+		origErrs = be.OrigErrs()
+	} else if orig := e.OrigErr(); orig != nil {
+		origErrs = []error{orig}
+	}
+
+	var statusCode int
+	if rf, ok := err.(awserr.RequestFailure); ok {
+		statusCode = rf.StatusCode()
+		_ = c.AddTypes("Request").AddTags(
+			errors.T("status_code", statusCode),
+			errors.T("request_id", rf.RequestID()),
 		)
-		return
+	} else if isBatch(err, e.Code()) {
+		_ = c.AddTypes("Batch")
+	} else {
+		_ = c.AddTypes("General", "Error")
+	}
+	_ = c.AddTags(errors.T("aws_error_code", e.Code()))
+
+	switch {
+	case isThrottle(err, e.Code(), statusCode):
+		_ = c.AddTypes(transient, throttled).AddKinds(kinds.ResourceExhausted)
+	case isRetryable(err), statusCode >= 500:
+		_ = c.AddTypes(transient)
+		addStatusKind(c, statusCode, errors.Permanent)
+	case statusCode >= 400:
+		_ = c.AddTypes(permanent)
+		addStatusKind(c, statusCode, errors.Transient)
+	default:
+		_ = c.AddTypes(permanent)
+	}
+
+	for _, orig := range origErrs {
+		if orig != nil {
+			addOrigErr(c, orig)
+		}
+	}
+	return
+}
+
+// addStatusKind adds the well-known Kind of the status code, unless it contradicts the classification by descending
+// from exclude.
+func addStatusKind(c errors.Chain, statusCode int, exclude errors.Kind) {
+	if k, ok := kinds.FromHTTPStatus(statusCode); ok && !k.Is(exclude) {
+		_ = c.AddKinds(k)
+	}
+}
+
+// isBatch returns if the error is a batch of errors. awserr.New and awserr.NewBatchError return the same type, so the
+// SDKs batch code identifies a batch of any size, otherwise errors with more than one original error are batches.
+func isBatch(err error, code string) bool {
+	be, ok := err.(awserr.BatchedErrors)
+	return ok && (code == batchedErrorsCode || len(be.OrigErrs()) > 1)
+}
+
+func isThrottle(err error, code string, statusCode int) bool {
+	if _, ok := throttleCodes[code]; ok {
+		return true
+	}
+	return statusCode == 429 || request.IsErrorThrottle(err)
+}
+
+// isRetryable guards against request.IsErrorRetryable recursing infinitely on batched errors, as their OrigErr returns
+// a new batch containing the same errors, by classifying the batched errors individually.
+func isRetryable(err error) bool {
+	for e := err; e != nil; {
+		ae, ok := e.(awserr.Error)
+		if !ok {
+			break
+		}
+		if be, ok := e.(awserr.BatchedErrors); ok && len(be.OrigErrs()) > 1 {
+			for _, oe := range be.OrigErrs() {
+				if isRetryable(oe) {
+					return true
+				}
+			}
+			return false
+		}
+		e = ae.OrigErr()
+	}
+	return request.IsErrorRetryable(err)
+}
+
+// addOrigErr runs the registered helpers against the original error and adds the resulting Types and Tags.
+// Permanent and Transient, and Kinds descending from them, are not added as the AWS classification takes precedence.
+func addOrigErr(c errors.Chain, orig error) {
+	var links errors.Chain
+	if oc, ok := orig.(errors.Chain); ok {
+		links = oc
+	} else {
+		links = errors.Chain{&errors.Link{Err: orig}}
+		errors.RunHelpers(links, orig)
+	}
 
-	case awserr.Error:
-		_ = c.AddTypes("General", "Error").AddTags(errors.T("aws_error_code", e.Code()))
-		return
+	l := c[len(c)-1]
+	for _, ol := range links {
+		for _, typ := range ol.Types {
+			k, _ := errors.LookupKind(typ)
+			if !k.Is(errors.Permanent) && !k.Is(errors.Transient) && !names.Contains(l.Types, typ) {
+				_ = c.AddTypes(typ)
+			}
+		}
+		_ = c.AddTags(ol.Tags...)
 	}
-	return true
 }
@@ -0,0 +1,97 @@
+package awserrors
+
+import (
+	"io"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/go-playground/errors/v6"
+	_ "github.com/go-playground/errors/v6/helpers/ioerrors"
+)
+
+func TestAWSErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		types []string
+		not   []string
+	}{
+		{
+			name:  "validation",
+			err:   awserr.NewRequestFailure(awserr.New("ValidationException", "invalid", nil), 400, "id"),
+			types: []string{"Request", permanent, "InvalidArgument"},
+			not:   []string{transient},
+		},
+		{
+			name:  "throttling",
+			err:   awserr.NewRequestFailure(awserr.New("ThrottlingException", "slow down", nil), 400, "id"),
+			types: []string{"Request", transient, throttled, "ResourceExhausted"},
+			not:   []string{permanent},
+		},
+		{
+			name:  "server error",
+			err:   awserr.NewRequestFailure(awserr.New("InternalFailure", "oops", nil), 503, "id"),
+			types: []string{"Request", transient, "Unavailable"},
+			not:   []string{permanent},
+		},
+		{
+			name:  "not implemented",
+			err:   awserr.NewRequestFailure(awserr.New("NotImplemented", "oops", nil), 501, "id"),
+			types: []string{"Request", transient},
+			not:   []string{permanent, "Unimplemented"},
+		},
+		{
+			name:  "retryable request timeout",
+			err:   awserr.NewRequestFailure(awserr.New("RequestTimeout", "timeout", nil), 400, "id"),
+			types: []string{"Request", transient},
+			not:   []string{permanent, "InvalidArgument"},
+		},
+		{
+			name:  "retryable expired token",
+			err:   awserr.NewRequestFailure(awserr.New("ExpiredTokenException", "expired", nil), 400, "id"),
+			types: []string{"Request", transient},
+			not:   []string{permanent, "InvalidArgument"},
+		},
+		{
+			name:  "permanent original error",
+			err:   awserr.NewRequestFailure(awserr.New("RequestTimeout", "timeout", io.ErrShortWrite), 400, "id"),
+			types: []string{"Request", transient, "io"},
+			not:   []string{permanent},
+		},
+		{
+			name:  "batch",
+			err:   awserr.NewBatchError("BatchedErrors", "multiple errors occurred", []error{io.EOF, io.ErrShortWrite}),
+			types: []string{"Batch", transient},
+		},
+		{
+			name:  "single error batch",
+			err:   awserr.NewBatchError("BatchedErrors", "multiple errors occurred", []error{io.ErrShortWrite}),
+			types: []string{"Batch"},
+			not:   []string{"General", "Error"},
+		},
+		{
+			name:  "nested batch",
+			err:   awserr.New("RequestError", "send request failed", awserr.NewBatchError("BatchedErrors", "multiple", []error{io.EOF, io.EOF})),
+			types: []string{"General", transient},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			err := errors.Wrap(tc.err, "prefix")
+			for _, typ := range tc.types {
+				if !errors.HasType(err, typ) {
+					t.Errorf("want type %s got %s", typ, err)
+				}
+			}
+			for _, typ := range tc.not {
+				if errors.HasType(err, typ) {
+					t.Errorf("want no type %s got %s", typ, err)
+				}
+			}
+			if errors.LookupTag(err, "aws_error_code") == nil {
+				t.Errorf("want aws_error_code tag got %s", err)
+			}
+		})
+	}
+}
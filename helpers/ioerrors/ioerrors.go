@@ -3,7 +3,8 @@ package ioerrors
 import (
 	"io"
 
-	"github.com/go-playground/errors/v5"
+	"github.com/go-playground/errors/v6"
+	"github.com/go-playground/errors/v6/kinds"
 )
 
 func init() {
@@ -17,19 +18,19 @@ func IOErrors(c errors.Chain, err error) (cont bool) {
 		_ = c.AddTypes("io")
 		return
 	case io.ErrClosedPipe:
-		_ = c.AddTypes("Permanent", "io")
+		_ = c.AddTypes("Permanent", "io").AddKinds(kinds.FailedPrecondition)
 		return
 	case io.ErrNoProgress:
-		_ = c.AddTypes("Permanent", "io")
+		_ = c.AddTypes("Permanent", "io").AddKinds(kinds.Internal)
 		return
 	case io.ErrShortBuffer:
-		_ = c.AddTypes("Permanent", "io")
+		_ = c.AddTypes("Permanent", "io").AddKinds(kinds.InvalidArgument)
 		return
 	case io.ErrShortWrite:
-		_ = c.AddTypes("Permanent", "io")
+		_ = c.AddTypes("Permanent", "io").AddKinds(kinds.Internal)
 		return
 	case io.ErrUnexpectedEOF:
-		_ = c.AddTypes("Transient", "io")
+		_ = c.AddTypes("Transient", "io").AddKinds(kinds.Aborted)
 		return
 	}
 	return true
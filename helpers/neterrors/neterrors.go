@@ -3,7 +3,8 @@ package neterrors
 import (
 	"net"
 
-	"github.com/go-playground/errors/v5"
+	"github.com/go-playground/errors/v6"
+	"github.com/go-playground/errors/v6/kinds"
 )
 
 const (
@@ -23,7 +24,7 @@ func NETErrors(c errors.Chain, err error) (cont bool) {
 		if e.Temporary() {
 			tp = transient
 		}
-		_ = c.AddTypes(tp, "net").AddTags(
+		_ = c.AddTypes(tp, "net").AddKinds(kind(e, kinds.InvalidArgument)).AddTags(
 			errors.T("addr", e.Addr),
 			errors.T("is_timeout", e.Timeout()),
 			errors.T("is_temporary", e.Temporary()),
@@ -35,7 +36,11 @@ func NETErrors(c errors.Chain, err error) (cont bool) {
 		if e.Temporary() {
 			tp = transient
 		}
-		_ = c.AddTypes(tp, "net").AddTags(
+		permanentKind := kinds.Internal
+		if e.IsNotFound {
+			permanentKind = kinds.NotFound
+		}
+		_ = c.AddTypes(tp, "net").AddKinds(kind(e, permanentKind)).AddTags(
 			errors.T("name", e.Name),
 			errors.T("server", e.Server),
 			errors.T("is_timeout", e.Timeout()),
@@ -44,7 +49,7 @@ func NETErrors(c errors.Chain, err error) (cont bool) {
 		return false
 
 	case *net.ParseError:
-		_ = c.AddTypes(permanent, "net").AddTags(
+		_ = c.AddTypes(permanent, "net").AddKinds(kinds.InvalidArgument).AddTags(
 			errors.T("type", e.Type),
 			errors.T("text", e.Text),
 		)
@@ -55,7 +60,7 @@ func NETErrors(c errors.Chain, err error) (cont bool) {
 		if e.Temporary() {
 			tp = transient
 		}
-		_ = c.AddTypes(tp, "net").AddTags(
+		_ = c.AddTypes(tp, "net").AddKinds(kind(e, kinds.Internal)).AddTags(
 			errors.T("op", e.Op),
 			errors.T("net", e.Net),
 			errors.T("addr", e.Addr),
@@ -69,7 +74,7 @@ func NETErrors(c errors.Chain, err error) (cont bool) {
 		if e.Temporary() {
 			tp = transient
 		}
-		_ = c.AddTypes(tp, "net").AddTags(
+		_ = c.AddTypes(tp, "net").AddKinds(kind(e, kinds.InvalidArgument)).AddTags(
 			errors.T("is_timeout", e.Timeout()),
 			errors.T("is_temporary", e.Temporary()),
 		)
@@ -77,8 +82,20 @@ func NETErrors(c errors.Chain, err error) (cont bool) {
 
 	switch err {
 	case net.ErrWriteToConnected:
-		_ = c.AddTypes(transient, "net")
+		_ = c.AddTypes(transient, "net").AddKinds(kinds.Aborted)
 		return false
 	}
 	return true
 }
+
+// kind returns DeadlineExceeded or Unavailable for temporary errors, consistent with their Transient classification,
+// otherwise permanentKind.
+func kind(e net.Error, permanentKind errors.Kind) errors.Kind {
+	switch {
+	case e.Temporary() && e.Timeout():
+		return kinds.DeadlineExceeded
+	case e.Temporary():
+		return kinds.Unavailable
+	}
+	return permanentKind
+}
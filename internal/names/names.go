@@ -0,0 +1,24 @@
+// Package names contains helpers for the function and type names shared by the errors packages.
+package names
+
+import "strings"
+
+// FuncPackage returns the package path of a fully qualified function name.
+func FuncPackage(fn string) string {
+	dot := strings.LastIndexByte(fn, '.')
+	if dot == -1 {
+		return fn
+	}
+	slash := strings.LastIndexByte(fn[:dot], '/')
+	return fn[:slash+1+strings.IndexByte(fn[slash+1:], '.')]
+}
+
+// Contains returns if the names contain the name.
+func Contains(names []string, name string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,32 @@
+package names
+
+import "testing"
+
+func TestFuncPackage(t *testing.T) {
+	tests := []struct {
+		fn  string
+		pkg string
+	}{
+		{fn: "github.com/go-playground/errors/v6.(*Link).Error", pkg: "github.com/go-playground/errors/v6"},
+		{fn: "github.com/go-playground/errors/v6.Wrap", pkg: "github.com/go-playground/errors/v6"},
+		{fn: "github.com/go-playground/errors/v6/errprof.(*Profile).record.func1", pkg: "github.com/go-playground/errors/v6/errprof"},
+		{fn: "gopkg.in/yaml%2ev3.Unmarshal", pkg: "gopkg.in/yaml%2ev3"},
+		{fn: "main.main", pkg: "main"},
+		{fn: "main", pkg: "main"},
+	}
+	for _, tc := range tests {
+		if pkg := FuncPackage(tc.fn); pkg != tc.pkg {
+			t.Errorf("want %s got %s", tc.pkg, pkg)
+		}
+	}
+}
+
+func TestContains(t *testing.T) {
+	names := []string{"a", "b"}
+	if !Contains(names, "b") {
+		t.Errorf("want b contained")
+	}
+	if Contains(names, "c") || Contains(nil, "a") {
+		t.Errorf("want c and nil not contained")
+	}
+}
@@ -0,0 +1,136 @@
+package errors
+
+import "fmt"
+
+// Kind is a declared type of error, which may have one or more parent Kinds, eg. Throttled is a Transient error.
+//
+// Kinds are stored in Link.Types as strings so they interoperate with AddTypes and helpers; HasType, TypeTarget and
+// LinkTarget resolve them through the hierarchy so an error with the Throttled type also has the Transient type.
+//
+// A Kind can only be obtained from RegisterKind or LookupKind, so a misspelt Kind fails to compile rather than silently
+// becoming a new type.
+type Kind struct {
+	name string
+}
+
+// The built-in Kinds.
+var (
+	// Permanent is an error which will not succeed if retried.
+	Permanent = RegisterKind("Permanent")
+
+	// Transient is an error which may succeed if retried.
+	Transient = RegisterKind("Transient")
+
+	// Throttled is a Transient error caused by rate limiting.
+	Throttled = RegisterKind("Throttled", Transient)
+
+	// Panic is an error recovered from a panic, see Recover.
+	Panic = RegisterKind(panicType)
+
+	// RuntimeError is a Panic caused by a runtime.Error.
+	RuntimeError = RegisterKind(runtimeErrorType, Panic)
+)
+
+type kindInfo struct {
+	parents   []Kind
+	ancestors map[string]struct{}
+}
+
+var (
+	kinds     map[string]*kindInfo
+	kindOrder []Kind
+)
+
+// RegisterKind declares a Kind with the provided parents, which must already be declared, and returns it.
+//
+// RegisterKind panics if the Kind is already declared or a parent is not, ensuring typos are caught at
+// initialization and that the hierarchy cannot contain cycles.
+// NOTE: this is not concurrency safe and should be called during initialization.
+func RegisterKind(name string, parents ...Kind) Kind {
+	k := Kind{name: name}
+	if name == "" {
+		panic("errors: Kind name must not be empty")
+	}
+	if _, ok := kinds[name]; ok {
+		panic(fmt.Sprintf("errors: Kind %q is already declared", name))
+	}
+	info := &kindInfo{parents: append([]Kind(nil), parents...)}
+	for _, p := range parents {
+		pi, ok := kinds[p.name]
+		if !ok {
+			panic(fmt.Sprintf("errors: parent Kind %q of %q is not declared", p, name))
+		}
+		if info.ancestors == nil {
+			info.ancestors = make(map[string]struct{})
+		}
+		info.ancestors[p.name] = struct{}{}
+		for a := range pi.ancestors {
+			info.ancestors[a] = struct{}{}
+		}
+	}
+	if kinds == nil {
+		kinds = make(map[string]*kindInfo)
+	}
+	kinds[name] = info
+	kindOrder = append(kindOrder, k)
+	return k
+}
+
+// Kinds returns all declared Kinds in the order they were declared, eg. for documentation.
+func Kinds() []Kind {
+	return append([]Kind(nil), kindOrder...)
+}
+
+// LookupKind returns the declared Kind with the name, eg. to resolve the Kinds of Link.Types.
+func LookupKind(name string) (Kind, bool) {
+	if _, ok := kinds[name]; !ok {
+		return Kind{}, false
+	}
+	return Kind{name: name}, true
+}
+
+// String returns the Kinds name.
+func (k Kind) String() string {
+	return k.name
+}
+
+// Declared returns if the Kind has been declared using RegisterKind, which is only false for the zero Kind.
+func (k Kind) Declared() bool {
+	_, ok := kinds[k.name]
+	return ok
+}
+
+// Parents returns the direct parents of the Kind.
+func (k Kind) Parents() []Kind {
+	if info, ok := kinds[k.name]; ok {
+		return append([]Kind(nil), info.parents...)
+	}
+	return nil
+}
+
+// Is returns if the Kind is the target or has it as an ancestor.
+func (k Kind) Is(target Kind) bool {
+	return kindIs(k.name, target.name)
+}
+
+// kindIs returns if the type is the target or a declared Kind with the target as an ancestor.
+func kindIs(typ, target string) bool {
+	if typ == target {
+		return true
+	}
+	if info, ok := kinds[typ]; ok {
+		_, ok = info.ancestors[target]
+		return ok
+	}
+	return false
+}
+
+// AddKinds sets one or more Kinds on the Link error, see AddTypes.
+func (c Chain) AddKinds(ks ...Kind) Chain {
+	c, l := c.mutable()
+	for _, k := range ks {
+		l.Types = append(l.Types, k.name)
+	}
+	notifyTypes(c, l, l.Types[len(l.Types)-len(ks):])
+	return c
+}
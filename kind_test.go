@@ -0,0 +1,102 @@
+package errors
+
+import (
+	"io"
+	"reflect"
+	"testing"
+)
+
+var (
+	testClientError = RegisterKind("test.ClientError", Permanent)
+	testNotFound    = RegisterKind("test.NotFound", testClientError)
+)
+
+func TestKindHierarchy(t *testing.T) {
+	tests := []struct {
+		kind     Kind
+		target   Kind
+		expected bool
+	}{
+		{kind: Throttled, target: Throttled, expected: true},
+		{kind: Throttled, target: Transient, expected: true},
+		{kind: Transient, target: Throttled, expected: false},
+		{kind: testNotFound, target: testClientError, expected: true},
+		{kind: testNotFound, target: Permanent, expected: true},
+		{kind: testNotFound, target: Transient, expected: false},
+		{kind: Kind{}, target: Permanent, expected: false},
+	}
+
+	for i, tt := range tests {
+		if actual := tt.kind.Is(tt.target); actual != tt.expected {
+			t.Errorf("#%d %s.Is(%s) want %t got %t", i, tt.kind, tt.target, tt.expected, actual)
+		}
+	}
+
+	if !reflect.DeepEqual(testNotFound.Parents(), []Kind{testClientError}) {
+		t.Errorf("want parents %v got %v", []Kind{testClientError}, testNotFound.Parents())
+	}
+	if !Throttled.Declared() || (Kind{}).Declared() {
+		t.Error("want only registered Kinds to be declared")
+	}
+	if k, ok := LookupKind("Throttled"); !ok || k != Throttled {
+		t.Errorf("want declared Kind got %v", k)
+	}
+	if _, ok := LookupKind("Transiant"); ok {
+		t.Error("want undeclared Kind not found")
+	}
+}
+
+func TestKindHasType(t *testing.T) {
+	err := Wrap(io.EOF, "prefix").AddKinds(testNotFound)
+	err = err.Wrap("outer").AddTypes("Throttled")
+
+	for _, typ := range []string{"test.NotFound", "test.ClientError", "Permanent", "Throttled", "Transient"} {
+		if !HasType(err, typ) {
+			t.Errorf("want type %s", typ)
+		}
+		if !Is(err, TypeTarget(typ)) {
+			t.Errorf("want TypeTarget %s to match", typ)
+		}
+	}
+	if HasType(Wrap(io.EOF, "prefix").AddKinds(Transient), "Throttled") {
+		t.Error("want parent Kind not to match child")
+	}
+
+	target := LinkByType(Permanent.String())
+	if !As(err, target) || target.Link.Prefix != "prefix" {
+		t.Fatal("want Link with descendant Kind to match")
+	}
+}
+
+func TestKinds(t *testing.T) {
+	ks := Kinds()
+	if len(ks) < 7 || ks[0] != Permanent {
+		t.Fatalf("want all declared Kinds in declaration order got %v", ks)
+	}
+	ks[0] = Transient
+	if Kinds()[0] != Permanent {
+		t.Fatal("want Kinds to return a copy")
+	}
+}
+
+func TestRegisterKindPanics(t *testing.T) {
+	tests := []struct {
+		name    string
+		parents []Kind
+	}{
+		{name: ""},
+		{name: "Permanent"},
+		{name: "test.Orphan", parents: []Kind{{}}},
+	}
+
+	for i, tt := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("#%d want panic", i)
+				}
+			}()
+			RegisterKind(tt.name, tt.parents...)
+		}()
+	}
+}
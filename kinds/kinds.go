@@ -0,0 +1,224 @@
+// Package kinds provides well-known error Kinds along with their canonical HTTP status, gRPC code and sysexits.h exit
+// code, allowing errors to be consistently classified and mapped to responses and exit codes.
+//
+//	err = errors.Wrap(err, "user not found").AddKinds(kinds.NotFound)
+//	...
+//	w.WriteHeader(kinds.HTTPStatus(err)) // 404
+package kinds
+
+import (
+	"net/http"
+
+	"github.com/go-playground/errors/v6"
+)
+
+// The well-known Kinds, modeled after the gRPC status codes.
+var (
+	// InvalidArgument is an error caused by an invalid argument or input.
+	InvalidArgument = errors.RegisterKind("InvalidArgument", errors.Permanent)
+
+	// NotFound is an error caused by a requested entity not existing.
+	NotFound = errors.RegisterKind("NotFound", errors.Permanent)
+
+	// AlreadyExists is an error caused by an entity to be created already existing.
+	AlreadyExists = errors.RegisterKind("AlreadyExists", errors.Permanent)
+
+	// PermissionDenied is an error caused by the caller not having permission to perform the operation.
+	PermissionDenied = errors.RegisterKind("PermissionDenied", errors.Permanent)
+
+	// Unauthenticated is an error caused by the caller not having valid authentication credentials.
+	Unauthenticated = errors.RegisterKind("Unauthenticated", errors.Permanent)
+
+	// ResourceExhausted is an error caused by a quota or rate limit being exceeded, or running out of a resource.
+	ResourceExhausted = errors.RegisterKind("ResourceExhausted", errors.Transient)
+
+	// FailedPrecondition is an error caused by the system not being in the state required by the operation.
+	FailedPrecondition = errors.RegisterKind("FailedPrecondition", errors.Permanent)
+
+	// Aborted is an error caused by the operation being aborted, usually due to a concurrency conflict.
+	Aborted = errors.RegisterKind("Aborted", errors.Transient)
+
+	// Unavailable is an error caused by a service being unavailable.
+	Unavailable = errors.RegisterKind("Unavailable", errors.Transient)
+
+	// DeadlineExceeded is an error caused by the operation not completing before its deadline.
+	DeadlineExceeded = errors.RegisterKind("DeadlineExceeded", errors.Transient)
+
+	// Internal is an error caused by a broken invariant or unexpected failure.
+	Internal = errors.RegisterKind("Internal")
+
+	// Unimplemented is an error caused by the operation not being implemented or supported.
+	Unimplemented = errors.RegisterKind("Unimplemented", errors.Permanent)
+)
+
+// gRPC compatible codes, see google.golang.org/grpc/codes.
+const (
+	grpcOK                 uint32 = 0
+	grpcUnknown            uint32 = 2
+	grpcInvalidArgument    uint32 = 3
+	grpcDeadlineExceeded   uint32 = 4
+	grpcNotFound           uint32 = 5
+	grpcAlreadyExists      uint32 = 6
+	grpcPermissionDenied   uint32 = 7
+	grpcResourceExhausted  uint32 = 8
+	grpcFailedPrecondition uint32 = 9
+	grpcAborted            uint32 = 10
+	grpcUnimplemented      uint32 = 12
+	grpcInternal           uint32 = 13
+	grpcUnavailable        uint32 = 14
+	grpcUnauthenticated    uint32 = 16
+)
+
+// sysexits.h exit codes.
+const (
+	exUsage       = 64
+	exNoInput     = 66
+	exUnavailable = 69
+	exSoftware    = 70
+	exCantCreate  = 73
+	exTempFail    = 75
+	exNoPerm      = 77
+	exConfig      = 78
+)
+
+// Codes contains the canonical codes of a Kind.
+type Codes struct {
+
+	// HTTPStatus is the HTTP status code
+	HTTPStatus int
+
+	// GRPCCode is the gRPC compatible status code
+	GRPCCode uint32
+
+	// ExitCode is the sysexits.h process exit code
+	ExitCode int
+}
+
+type mapping struct {
+	kind  errors.Kind
+	codes Codes
+}
+
+// mappings contains the well-known Kinds and their codes in order of precedence.
+var mappings = []mapping{
+	{kind: InvalidArgument, codes: Codes{HTTPStatus: http.StatusBadRequest, GRPCCode: grpcInvalidArgument, ExitCode: exUsage}},
+	{kind: NotFound, codes: Codes{HTTPStatus: http.StatusNotFound, GRPCCode: grpcNotFound, ExitCode: exNoInput}},
+	{kind: AlreadyExists, codes: Codes{HTTPStatus: http.StatusConflict, GRPCCode: grpcAlreadyExists, ExitCode: exCantCreate}},
+	{kind: PermissionDenied, codes: Codes{HTTPStatus: http.StatusForbidden, GRPCCode: grpcPermissionDenied, ExitCode: exNoPerm}},
+	{kind: Unauthenticated, codes: Codes{HTTPStatus: http.StatusUnauthorized, GRPCCode: grpcUnauthenticated, ExitCode: exNoPerm}},
+	{kind: ResourceExhausted, codes: Codes{HTTPStatus: http.StatusTooManyRequests, GRPCCode: grpcResourceExhausted, ExitCode: exTempFail}},
+	{kind: FailedPrecondition, codes: Codes{HTTPStatus: http.StatusBadRequest, GRPCCode: grpcFailedPrecondition, ExitCode: exConfig}},
+	{kind: Aborted, codes: Codes{HTTPStatus: http.StatusConflict, GRPCCode: grpcAborted, ExitCode: exTempFail}},
+	{kind: Unavailable, codes: Codes{HTTPStatus: http.StatusServiceUnavailable, GRPCCode: grpcUnavailable, ExitCode: exTempFail}},
+	{kind: DeadlineExceeded, codes: Codes{HTTPStatus: http.StatusGatewayTimeout, GRPCCode: grpcDeadlineExceeded, ExitCode: exTempFail}},
+	{kind: Internal, codes: Codes{HTTPStatus: http.StatusInternalServerError, GRPCCode: grpcInternal, ExitCode: exSoftware}},
+	{kind: Unimplemented, codes: Codes{HTTPStatus: http.StatusNotImplemented, GRPCCode: grpcUnimplemented, ExitCode: exUnavailable}},
+}
+
+// unknown contains the codes of errors without a well-known Kind.
+var unknown = Codes{HTTPStatus: http.StatusInternalServerError, GRPCCode: grpcUnknown, ExitCode: exSoftware}
+
+// All returns the well-known Kinds.
+func All() []errors.Kind {
+	ks := make([]errors.Kind, len(mappings))
+	for i, m := range mappings {
+		ks[i] = m.kind
+	}
+	return ks
+}
+
+// CodesOf returns the codes of the well-known Kind, or Kind descending from it.
+func CodesOf(k errors.Kind) (Codes, bool) {
+	for _, m := range mappings {
+		if k.Is(m.kind) {
+			return m.codes, true
+		}
+	}
+	return Codes{}, false
+}
+
+// Of returns the well-known Kind of the error, if any.
+func Of(err error) (errors.Kind, bool) {
+	m, ok := mappingOf(err)
+	return m.kind, ok
+}
+
+// HTTPStatus returns the HTTP status code of the error; 200 when nil and 500 when it has no well-known Kind.
+func HTTPStatus(err error) int {
+	if err == nil {
+		return http.StatusOK
+	}
+	return codesOf(err).HTTPStatus
+}
+
+// GRPCCode returns the gRPC compatible status code of the error; OK when nil and Unknown when it has no well-known
+// Kind.
+func GRPCCode(err error) uint32 {
+	if err == nil {
+		return grpcOK
+	}
+	return codesOf(err).GRPCCode
+}
+
+// ExitCode returns the sysexits.h exit code of the error; 0 when nil and EX_SOFTWARE when it has no well-known Kind.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	return codesOf(err).ExitCode
+}
+
+// FromHTTPStatus returns the well-known Kind of the HTTP error status code, if any.
+func FromHTTPStatus(status int) (errors.Kind, bool) {
+	switch status {
+	case http.StatusBadRequest:
+		return InvalidArgument, true
+	case http.StatusUnauthorized:
+		return Unauthenticated, true
+	case http.StatusForbidden:
+		return PermissionDenied, true
+	case http.StatusNotFound:
+		return NotFound, true
+	case http.StatusConflict:
+		return AlreadyExists, true
+	case http.StatusPreconditionFailed:
+		return FailedPrecondition, true
+	case http.StatusTooManyRequests:
+		return ResourceExhausted, true
+	case http.StatusInternalServerError:
+		return Internal, true
+	case http.StatusNotImplemented:
+		return Unimplemented, true
+	case http.StatusBadGateway, http.StatusServiceUnavailable:
+		return Unavailable, true
+	case http.StatusGatewayTimeout:
+		return DeadlineExceeded, true
+	}
+	return errors.Kind{}, false
+}
+
+// FromGRPCCode returns the well-known Kind of the gRPC status code, if any.
+func FromGRPCCode(code uint32) (errors.Kind, bool) {
+	for _, m := range mappings {
+		if m.codes.GRPCCode == code {
+			return m.kind, true
+		}
+	}
+	return errors.Kind{}, false
+}
+
+func codesOf(err error) Codes {
+	if m, ok := mappingOf(err); ok {
+		return m.codes
+	}
+	return unknown
+}
+
+func mappingOf(err error) (mapping, bool) {
+	for _, m := range mappings {
+		if errors.HasType(err, m.kind.String()) {
+			return m, true
+		}
+	}
+	return mapping{}, false
+}
@@ -0,0 +1,77 @@
+package kinds
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+)
+
+var testUserNotFound = errors.RegisterKind("kinds.test.UserNotFound", NotFound)
+
+func TestCodes(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		grpc   uint32
+		exit   int
+	}{
+		{name: "nil", err: nil, status: http.StatusOK, grpc: 0, exit: 0},
+		{name: "unknown", err: errors.New("unknown"), status: http.StatusInternalServerError, grpc: 2, exit: 70},
+		{name: "not found", err: errors.Wrap(io.EOF, "prefix").AddKinds(NotFound), status: http.StatusNotFound, grpc: 5, exit: 66},
+		{name: "descendant", err: errors.New("user").AddKinds(testUserNotFound), status: http.StatusNotFound, grpc: 5, exit: 66},
+		{name: "throttled", err: errors.New("slow down").AddKinds(ResourceExhausted), status: http.StatusTooManyRequests, grpc: 8, exit: 75},
+		{name: "unavailable", err: errors.New("down").AddKinds(Unavailable), status: http.StatusServiceUnavailable, grpc: 14, exit: 75},
+	}
+
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := HTTPStatus(tc.err); got != tc.status {
+				t.Errorf("want HTTP status %d got %d", tc.status, got)
+			}
+			if got := GRPCCode(tc.err); got != tc.grpc {
+				t.Errorf("want gRPC code %d got %d", tc.grpc, got)
+			}
+			if got := ExitCode(tc.err); got != tc.exit {
+				t.Errorf("want exit code %d got %d", tc.exit, got)
+			}
+		})
+	}
+}
+
+func TestHierarchy(t *testing.T) {
+	err := errors.New("slow down").AddKinds(ResourceExhausted)
+	if !errors.HasType(err, "Transient") || errors.HasType(err, "Permanent") {
+		t.Fatalf("want ResourceExhausted to be Transient got %s", err)
+	}
+	if k, ok := Of(errors.Wrap(err, "prefix")); !ok || k != ResourceExhausted {
+		t.Fatalf("want ResourceExhausted got %s", k)
+	}
+	if _, ok := Of(io.EOF); ok {
+		t.Fatal("want no Kind")
+	}
+	if codes, ok := CodesOf(testUserNotFound); !ok || codes.HTTPStatus != http.StatusNotFound {
+		t.Fatalf("want NotFound codes got %+v", codes)
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, k := range All() {
+		codes, ok := CodesOf(k)
+		if !ok {
+			t.Fatalf("want codes for %s", k)
+		}
+		if got, ok := FromGRPCCode(codes.GRPCCode); !ok || got != k {
+			t.Errorf("want gRPC code %d to map to %s got %s", codes.GRPCCode, k, got)
+		}
+		if got, ok := FromHTTPStatus(codes.HTTPStatus); !ok || (got != k && codes.HTTPStatus != http.StatusConflict && codes.HTTPStatus != http.StatusBadRequest) {
+			t.Errorf("want HTTP status %d to map to %s got %s", codes.HTTPStatus, k, got)
+		}
+	}
+	if _, ok := FromHTTPStatus(http.StatusTeapot); ok {
+		t.Fatal("want no Kind")
+	}
+}
@@ -0,0 +1,97 @@
+package errors
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Observer is called with the resulting Chain and the newly added Link each time an error is created or wrapped,
+// eg. to record metrics or audit errors.
+//
+// Observers run synchronously, in the order they were registered, on the goroutine creating or wrapping the error and
+// before the function doing so returns. They must therefore be fast, safe for concurrent use and must not modify the
+// Chain or Link, nor create or wrap errors themselves. Types added afterwards, using AddTypes and AddKinds, are
+// reported to TypesObservers, see OnTypes, while Tags added afterwards are not visible to them.
+type Observer func(c Chain, l *Link)
+
+// TypesObserver is called with the resulting Chain, the Link and the types added to it each time types are added to
+// an existing error using AddTypes or AddKinds, eg. to count errors by type. Types added by the helpers while an error
+// is being created are included in the Chain passed to the Observers of OnNew instead.
+//
+// TypesObservers have the same guarantees and restrictions as an Observer and must not modify the types.
+type TypesObserver func(c Chain, l *Link, types []string)
+
+type observer struct {
+	fn    Observer
+	types TypesObserver
+}
+
+var (
+	observersMu    sync.Mutex
+	newObservers   atomic.Value // []*observer
+	wrapObservers  atomic.Value // []*observer
+	typesObservers atomic.Value // []*observer
+)
+
+// OnNew registers an Observer called each time a new Chain is created, by New, Newf, wrapping an error which is not a
+// Chain or recovering a panic, and returns a function which unregisters it. The Link is the outermost Link of the new
+// Chain.
+func OnNew(fn Observer) (unsubscribe func()) {
+	return subscribe(&newObservers, &observer{fn: fn})
+}
+
+// OnWrap registers an Observer called each time a Link is added to an existing Chain, by Wrap, Wrapf or Chain.Wrap,
+// and returns a function which unregisters it.
+func OnWrap(fn Observer) (unsubscribe func()) {
+	return subscribe(&wrapObservers, &observer{fn: fn})
+}
+
+// OnTypes registers a TypesObserver called each time types are added to an existing error, by AddTypes or AddKinds,
+// and returns a function which unregisters it.
+func OnTypes(fn TypesObserver) (unsubscribe func()) {
+	return subscribe(&typesObservers, &observer{types: fn})
+}
+
+// subscribe adds the observer to the list, copying it so that notify never requires a lock.
+func subscribe(list *atomic.Value, o *observer) (unsubscribe func()) {
+	observersMu.Lock()
+	defer observersMu.Unlock()
+	current, _ := list.Load().([]*observer)
+	list.Store(append(current[:len(current):len(current)], o))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			observersMu.Lock()
+			defer observersMu.Unlock()
+			current, _ := list.Load().([]*observer)
+			updated := make([]*observer, 0, len(current))
+			for _, existing := range current {
+				if existing != o {
+					updated = append(updated, existing)
+				}
+			}
+			list.Store(updated)
+		})
+	}
+}
+
+// notify calls the Observers in the list.
+func notify(list *atomic.Value, c Chain, l *Link) {
+	observers, _ := list.Load().([]*observer)
+	for _, o := range observers {
+		o.fn(c, l)
+	}
+}
+
+// notifyTypes calls the TypesObservers with the types added to the Link, unless it is still being built by the helpers
+// in which case the types are visible to the Observers of OnNew.
+func notifyTypes(c Chain, l *Link, types []string) {
+	if l.extension().building {
+		return
+	}
+	observers, _ := typesObservers.Load().([]*observer)
+	for _, o := range observers {
+		o.types(c, l, types)
+	}
+}
@@ -0,0 +1,79 @@
+package errors
+
+import (
+	"io"
+	"sync"
+	"testing"
+)
+
+func TestObservers(t *testing.T) {
+	var created, wrapped []string
+	unsubscribeNew := OnNew(func(c Chain, l *Link) {
+		if l != c.current() {
+			t.Error("want the new Link to be the outermost Link")
+		}
+		created = append(created, l.Prefix)
+	})
+	unsubscribeWrap := OnWrap(func(c Chain, l *Link) {
+		if l != c.current() {
+			t.Error("want the new Link to be the outermost Link")
+		}
+		wrapped = append(wrapped, l.Prefix)
+	})
+
+	c := New("new")
+	c = Wrap(c, "wrap")
+	_ = c.Wrap("chain wrap")
+	_ = Wrap(io.EOF, "std")
+	_ = FromPanic("boom")
+
+	unsubscribeNew()
+	unsubscribeNew()
+	unsubscribeWrap()
+	_ = Wrap(New("after"), "after")
+
+	if len(created) != 3 || created[0] != "" || created[1] != "std" || created[2] != panicPrefix {
+		t.Errorf("want new observer called for new Chains got %q", created)
+	}
+	if len(wrapped) != 2 || wrapped[0] != "wrap" || wrapped[1] != "chain wrap" {
+		t.Errorf("want wrap observer called for wrapped Chains got %q", wrapped)
+	}
+}
+
+func TestTypesObserver(t *testing.T) {
+	var added [][]string
+	unsubscribe := OnTypes(func(c Chain, l *Link, types []string) {
+		if l != c.current() {
+			t.Error("want the Link to be the outermost Link")
+		}
+		added = append(added, append([]string(nil), types...))
+	})
+	defer unsubscribe()
+
+	_ = New("new").AddTypes("NotFound").AddTag("key", "value").AddKinds(Permanent, Transient)
+	_ = FromPanic(io.ErrUnexpectedEOF)
+	RunHelpers(Chain{&Link{Err: io.EOF}}, io.EOF)
+
+	if len(added) != 2 || len(added[0]) != 1 || added[0][0] != "NotFound" || len(added[1]) != 2 ||
+		added[1][0] != "Permanent" || added[1][1] != "Transient" {
+		t.Errorf("want types observer called with the types added after creation got %q", added)
+	}
+}
+
+func TestObserversConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unsubscribe := OnNew(func(Chain, *Link) {})
+			_ = New("new")
+			unsubscribe()
+		}()
+	}
+	wg.Wait()
+
+	if observers, _ := newObservers.Load().([]*observer); len(observers) != 0 {
+		t.Fatalf("want all observers unsubscribed got %d", len(observers))
+	}
+}
@@ -0,0 +1,119 @@
+// Package expvarcounts provides an errors.Observer which counts created errors by type and source function and
+// exposes the counts using expvar.
+//
+//	func init() {
+//		expvarcounts.Publish("errors")
+//	}
+//
+// The counts are then available from /debug/vars eg.
+//
+//	"errors": {"sources": {"main.load": 2}, "total": 2, "types": {"Permanent": 2, "io": 2}}
+//
+// Errors are counted when they are created, see errors.OnNew, and each type is counted once per error, whether it is
+// known when the error is created or added afterwards using AddTypes or AddKinds, see errors.OnTypes.
+package expvarcounts
+
+import (
+	"expvar"
+
+	"github.com/go-playground/errors/v6"
+	"github.com/go-playground/errors/v6/internal/names"
+)
+
+// Counts contains the counts of created errors, see errors.OnNew.
+type Counts struct {
+	m           *expvar.Map
+	total       *expvar.Int
+	types       *expvar.Map
+	sources     *expvar.Map
+	unsubscribe []func()
+}
+
+// New returns Counts which count all errors created from now on, without publishing them, see Publish.
+func New() *Counts {
+	c := &Counts{
+		m:       new(expvar.Map).Init(),
+		total:   new(expvar.Int),
+		types:   new(expvar.Map).Init(),
+		sources: new(expvar.Map).Init(),
+	}
+	c.m.Set("total", c.total)
+	c.m.Set("types", c.types)
+	c.m.Set("sources", c.sources)
+	c.unsubscribe = []func(){errors.OnNew(c.observe), errors.OnTypes(c.observeTypes)}
+	return c
+}
+
+// Publish returns Counts which count all errors created from now on, published as the expvar with the name.
+//
+// Like expvar.Publish it panics if the name is already registered.
+func Publish(name string) *Counts {
+	c := New()
+	expvar.Publish(name, c.m)
+	return c
+}
+
+// observe counts the new Chain once, and each distinct type of its Links once.
+func (c *Counts) observe(chain errors.Chain, l *errors.Link) {
+	c.total.Add(1)
+	c.sources.Add(l.Source.Frame.Function, 1)
+
+	var counted []string
+	for _, link := range chain {
+		for _, typ := range link.Types {
+			if !names.Contains(counted, typ) {
+				counted = append(counted, typ)
+				c.types.Add(typ, 1)
+			}
+		}
+	}
+}
+
+// observeTypes counts each type added to the error which it did not already have.
+func (c *Counts) observeTypes(chain errors.Chain, l *errors.Link, types []string) {
+	// the types of the Link before they were added
+	counted := l.Types[: len(l.Types)-len(types) : len(l.Types)-len(types)]
+	for _, link := range chain[:len(chain)-1] {
+		counted = append(counted, link.Types...)
+	}
+	for _, typ := range types {
+		if !names.Contains(counted, typ) {
+			counted = append(counted, typ)
+			c.types.Add(typ, 1)
+		}
+	}
+}
+
+// Var returns the expvar.Var containing the counts.
+func (c *Counts) Var() expvar.Var {
+	return c.m
+}
+
+// Total returns the number of errors created.
+func (c *Counts) Total() int64 {
+	return c.total.Value()
+}
+
+// Type returns the number of errors created with the type, or which it was added to afterwards.
+func (c *Counts) Type(typ string) int64 {
+	return value(c.types, typ)
+}
+
+// Source returns the number of errors created in the fully qualified function eg. `github.com/org/db.(*DB).Load`.
+func (c *Counts) Source(function string) int64 {
+	return value(c.sources, function)
+}
+
+// Stop stops counting errors, the counts are retained.
+func (c *Counts) Stop() {
+	for _, unsubscribe := range c.unsubscribe {
+		unsubscribe()
+	}
+}
+
+func value(m *expvar.Map, key string) int64 {
+	if v, ok := m.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
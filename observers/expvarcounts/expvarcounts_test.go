@@ -0,0 +1,81 @@
+package expvarcounts
+
+import (
+	"encoding/json"
+	"expvar"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/go-playground/errors/v6"
+)
+
+func newError() errors.Chain {
+	return errors.Wrap(io.EOF, "prefix").AddTypes("added")
+}
+
+func TestCounts(t *testing.T) {
+	c := New()
+	defer c.Stop()
+
+	_ = newError()
+	_ = newError().Wrap("wrapped")
+	_ = errors.New("new")
+
+	if c.Total() != 3 {
+		t.Fatalf("want 3 errors got %d", c.Total())
+	}
+	if got := c.Source("github.com/go-playground/errors/v6/observers/expvarcounts.newError"); got != 2 {
+		t.Fatalf("want 2 errors from newError got %d", got)
+	}
+	if got := c.Type("added"); got != 2 {
+		t.Fatalf("want types added after creation counted got %d", got)
+	}
+
+	var v struct {
+		Total   int64            `json:"total"`
+		Sources map[string]int64 `json:"sources"`
+	}
+	if err := json.Unmarshal([]byte(c.Var().String()), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Total != 3 || len(v.Sources) != 2 {
+		t.Fatalf("want counts got %s", c.Var())
+	}
+
+	c.Stop()
+	_ = newError()
+	if c.Total() != 3 {
+		t.Fatal("want counting stopped")
+	}
+}
+
+var publishOnce sync.Once
+
+func TestPublish(t *testing.T) {
+	// expvar names can only be published once per process, including when the test is run using -count
+	publishOnce.Do(func() {
+		c := Publish("expvarcounts_test")
+		defer c.Stop()
+		if expvar.Get("expvarcounts_test") != c.Var() {
+			t.Fatal("want counts published")
+		}
+	})
+}
+
+func TestCountsTypes(t *testing.T) {
+	c := New()
+	defer c.Stop()
+
+	_ = errors.FromPanic(io.EOF)
+	_ = errors.FromPanic("boom")
+	if c.Type("Panic") != 2 {
+		t.Fatalf("want Panic type counted got %d", c.Type("Panic"))
+	}
+
+	_ = errors.New("not found").AddTypes("NotFound", "NotFound").AddKinds(errors.Permanent)
+	_ = errors.FromPanic("boom").AddKinds(errors.Panic, errors.Transient).Wrap("outer").AddTypes("Transient")
+	if c.Type("NotFound") != 1 || c.Type("Permanent") != 1 || c.Type("Transient") != 1 || c.Type("Panic") != 3 {
+		t.Fatalf("want each type counted once per error got %s", c.Var())
+	}
+}
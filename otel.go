@@ -0,0 +1,109 @@
+package errors
+
+import (
+	"reflect"
+	"strconv"
+
+	"github.com/go-playground/errors/v6/internal/names"
+)
+
+// OpenTelemetry semantic convention attribute keys used by OTelAttributes.
+const (
+	OTelExceptionType       = "exception.type"
+	OTelExceptionMessage    = "exception.message"
+	OTelExceptionStacktrace = "exception.stacktrace"
+	OTelErrorTypes          = "error.types"
+	OTelErrorTagPrefix      = "error.tags."
+)
+
+// OTelAttributes converts the error into OpenTelemetry semantic convention exception attributes, as plain key value
+// Tags, so they can be recorded on a span without this module depending on OpenTelemetry.
+//
+//   - exception.type is the Go type of the root cause, see Cause.
+//   - exception.message is the compact error message, see CompactFormat and Format.
+//   - exception.stacktrace is the goroutine stack of a recovered panic, see Link.Stack, otherwise the source of each
+//     Link formatted like a Go stack trace.
+//   - error.types is a []string of the distinct types of all Links, if any.
+//   - error.tags.<key> contains the redacted value of every Tag, the Tag found by LookupTag is used when a key is
+//     repeated.
+func OTelAttributes(err error) []Tag {
+	if err == nil {
+		return nil
+	}
+	attrs := make([]Tag, 0, 4)
+	attrs = append(attrs,
+		Tag{Key: OTelExceptionType, Value: typeName(Cause(err))},
+		Tag{Key: OTelExceptionMessage, Value: otelMessage(err)},
+	)
+
+	var (
+		stack []byte
+		trace []byte
+		types []string
+		tags  []Tag
+	)
+	walkLinks(err, false, func(l *Link) bool {
+		if stack == nil {
+			stack = l.extension().stack
+		}
+		trace = appendStackFrame(trace, l)
+		return true
+	})
+	walkLinks(err, true, func(l *Link) bool {
+		for _, typ := range l.Types {
+			if !names.Contains(types, typ) {
+				types = append(types, typ)
+			}
+		}
+		for _, tag := range l.Tags {
+			if tagIndex(tags, tag.Key) == -1 {
+				tags = append(tags, tag)
+			}
+		}
+		return true
+	})
+
+	if stack != nil {
+		attrs = append(attrs, Tag{Key: OTelExceptionStacktrace, Value: string(stack)})
+	} else if trace != nil {
+		attrs = append(attrs, Tag{Key: OTelExceptionStacktrace, Value: string(trace)})
+	}
+	if len(types) > 0 {
+		attrs = append(attrs, Tag{Key: OTelErrorTypes, Value: types})
+	}
+	for _, tag := range tags {
+		attrs = append(attrs, Tag{Key: OTelErrorTagPrefix + tag.Key, Value: tag.RedactedValue()})
+	}
+	return attrs
+}
+
+// otelMessage returns the compact message of the error, with the text of any errors wrapping a Chain before it.
+func otelMessage(err error) string {
+	c := asFormatChain(err)
+	return string(appendCompact(make([]byte, 0, len(c)*32), c))
+}
+
+// typeName returns the package qualified name of the errors type, prefixed with * for pointer types, or its
+// description if unnamed.
+func typeName(err error) string {
+	t := reflect.TypeOf(err)
+	var ptr string
+	if t.Kind() == reflect.Ptr {
+		ptr = "*"
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" || t.Name() == "" {
+		return reflect.TypeOf(err).String()
+	}
+	return ptr + t.PkgPath() + "." + t.Name()
+}
+
+// appendStackFrame appends the Links source in the format of a Go stack trace frame.
+func appendStackFrame(b []byte, l *Link) []byte {
+	b = append(b, l.Source.Frame.Function...)
+	b = append(b, "()\n\t"...)
+	b = append(b, l.Source.Frame.File...)
+	b = append(b, ':')
+	b = strconv.AppendInt(b, int64(l.Source.Line()), 10)
+	return append(b, '\n')
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestOTelAttributes(t *testing.T) {
+	if OTelAttributes(nil) != nil {
+		t.Fatal("want no attributes for nil")
+	}
+
+	inner := Wrap(io.EOF, "inner").AddTypes("Permanent").AddTag("id", 1).AddTag("token", Secret("abc"))
+	err := Wrap(fmt.Errorf("std: %w", inner), "outer").AddTypes("io", "Permanent").AddTag("id", 2)
+
+	attrs := make(map[string]any)
+	for _, tag := range OTelAttributes(err) {
+		attrs[tag.Key] = tag.Value
+	}
+
+	if attrs[OTelExceptionType] != "*errors.errorString" {
+		t.Errorf("want root cause type got %v", attrs[OTelExceptionType])
+	}
+	if typ := typeName(LinkByTag("id")); typ != "*github.com/go-playground/errors/v6.LinkTarget" {
+		t.Errorf("want package qualified pointer type got %s", typ)
+	}
+	if typ := typeName(TypeTarget("id")); typ != "github.com/go-playground/errors/v6.TypeTarget" {
+		t.Errorf("want package qualified type got %s", typ)
+	}
+	if msg := OTelAttributes(inner)[1].Value; msg != "inner: EOF" {
+		t.Errorf("want compact message got %v", msg)
+	}
+	if msg := OTelAttributes(fmt.Errorf("std: %w", inner))[1].Value; msg != "std: inner: EOF" {
+		t.Errorf("want compact message of the wrapped Chain got %v", msg)
+	}
+	if msg := OTelAttributes(io.EOF)[1].Value; msg != "EOF" {
+		t.Errorf("want error text got %v", msg)
+	}
+	if !reflect.DeepEqual(attrs[OTelErrorTypes], []string{"io", "Permanent"}) {
+		t.Errorf("want distinct types got %v", attrs[OTelErrorTypes])
+	}
+	if attrs[OTelErrorTagPrefix+"id"] != 2 {
+		t.Errorf("want outermost tag got %v", attrs[OTelErrorTagPrefix+"id"])
+	}
+	if s, ok := attrs[OTelErrorTagPrefix+"token"].(SecretValue); !ok || s.Unredacted() != "abc" {
+		t.Errorf("want redacted tag got %v", attrs[OTelErrorTagPrefix+"token"])
+	}
+	stack, _ := attrs[OTelExceptionStacktrace].(string)
+	// a function and file line per Link of both Chains
+	if !strings.Contains(stack, ".TestOTelAttributes()\n\t") || strings.Count(stack, "\n") != 2*(len(inner)+len(err)) {
+		t.Errorf("want a frame per Link got %s", stack)
+	}
+}
+
+func TestOTelAttributesPanic(t *testing.T) {
+	attrs := make(map[string]any)
+	for _, tag := range OTelAttributes(Wrap(FromPanic("boom"), "recovered")) {
+		attrs[tag.Key] = tag.Value
+	}
+	if stack, _ := attrs[OTelExceptionStacktrace].(string); !strings.HasPrefix(stack, "goroutine ") {
+		t.Errorf("want panic stack got %s", stack)
+	}
+	if attrs[OTelErrorTagPrefix+"panic_value"] != "boom" {
+		t.Errorf("want panic value tag got %v", attrs[OTelErrorTagPrefix+"panic_value"])
+	}
+}
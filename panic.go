@@ -0,0 +1,104 @@
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+const (
+	panicType        = "Panic"
+	runtimeErrorType = "RuntimeError"
+	panicPrefix      = "panic"
+)
+
+// Recover recovers from a panic, if any, and sets err to the resulting Chain, see FromPanic.
+// It must be called directly using defer eg.
+//
+//	defer errors.Recover(&err)
+func Recover(err *error) {
+	if r := recover(); r != nil {
+		*err = fromPanic(r)
+	}
+}
+
+// FromPanic converts a value returned by recover() into a Chain, returning nil when the value is nil so that it can be
+// called unconditionally, eg.
+//
+//	defer func() {
+//		if c := errors.FromPanic(recover()); c != nil {
+//			err = c
+//		}
+//	}()
+//
+// The Chain's Link source is the panicking frame rather than the deferred function, it has the Panic type, and
+// RuntimeError type for runtime.Error panics, and contains the full goroutine stack, see Link.Stack.
+// If the panic value is an error or Chain it is wrapped, otherwise the value is stringified and added as the
+// panic_value Tag.
+func FromPanic(v any) Chain {
+	if v == nil {
+		return nil
+	}
+	return fromPanic(v)
+}
+
+func fromPanic(v any) (c Chain) {
+	err, _ := v.(error)
+	l := &Link{
+		Prefix: panicPrefix,
+		Types:  []string{panicType},
+		Source: panicFrame(),
+		ext:    &linkExt{stack: debug.Stack(), meta: std.resolve(err).newLinkMeta(nil)},
+	}
+
+	switch t := v.(type) {
+	case Chain:
+		if l.ext.meta != nil {
+			l.ext.meta.root = t.rootTime(l.ext.meta.root)
+		}
+		c = t.append(l)
+		notify(&wrapObservers, c, l)
+		traceLink(nil, c, "wrap")
+		return
+	case error:
+		l.Err = t
+		c = Chain{l}
+		RunHelpers(c, t)
+		if _, ok := t.(runtime.Error); ok {
+			l.Types = append(l.Types, runtimeErrorType)
+		}
+	default:
+		l.Err = stderrors.New(fmt.Sprint(v))
+		l.Tags = []Tag{{Key: "panic_value", Value: v}}
+		c = Chain{l}
+	}
+	notify(&newObservers, c, c.current())
+	traceLink(nil, c, "new")
+	return
+}
+
+// panicFrame returns the frame that caused the current panic, or the caller of Recover or FromPanic if the goroutine
+// is not panicking.
+func panicFrame() runtimeext.Frame {
+	var pcs [64]uintptr
+	n := runtime.Callers(4, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	caller, more := frames.Next()
+	panicking := caller.Function == "runtime.gopanic"
+
+	for more {
+		var f runtime.Frame
+		f, more = frames.Next()
+		if panicking && !strings.HasPrefix(f.Function, "runtime.") {
+			return runtimeext.Frame{Frame: f}
+		}
+		if f.Function == "runtime.gopanic" {
+			panicking = true
+		}
+	}
+	return runtimeext.Frame{Frame: caller}
+}
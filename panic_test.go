@@ -0,0 +1,108 @@
+package errors
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func panicNilDereference() (err error) {
+	defer Recover(&err)
+	var l *Link
+	_ = l.Prefix
+	return nil
+}
+
+func panicValue(v any) (err error) {
+	defer Recover(&err)
+	panic(v)
+}
+
+func panicFromPanic() (c Chain) {
+	defer func() {
+		c = FromPanic(recover())
+	}()
+	panic("from panic")
+}
+
+func TestRecover(t *testing.T) {
+	tests := []struct {
+		name  string
+		err   error
+		fn    string
+		types []string
+		is    error
+	}{
+		{
+			name:  "runtime error",
+			err:   panicNilDereference(),
+			fn:    "panicNilDereference",
+			types: []string{panicType, runtimeErrorType},
+		},
+		{
+			name:  "string value",
+			err:   panicValue("boom"),
+			fn:    "panicValue",
+			types: []string{panicType},
+		},
+		{
+			name:  "error value",
+			err:   panicValue(io.EOF),
+			fn:    "panicValue",
+			types: []string{panicType},
+			is:    io.EOF,
+		},
+		{
+			name:  "chain value",
+			err:   panicValue(Wrap(io.EOF, "prefix").AddTypes("Permanent")),
+			fn:    "panicValue",
+			types: []string{panicType, "Permanent"},
+			is:    io.EOF,
+		},
+		{
+			name:  "from panic",
+			err:   panicFromPanic(),
+			fn:    "panicFromPanic",
+			types: []string{panicType},
+		},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			c, ok := tc.err.(Chain)
+			if !ok {
+				t.Fatalf("want Chain got %T", tc.err)
+			}
+			link := c.current()
+			if link.Source.Function() != tc.fn {
+				t.Fatalf("want source %s got %s", tc.fn, link.Source.Function())
+			}
+			if !strings.Contains(string(link.Stack()), "panic_test.go") {
+				t.Fatalf("want full goroutine stack got %s", link.Stack())
+			}
+			for _, typ := range tc.types {
+				if !HasType(c, typ) {
+					t.Fatalf("want type %s in %s", typ, c)
+				}
+			}
+			if tc.is != nil && !Is(c, tc.is) {
+				t.Fatalf("want Is %v in %s", tc.is, c)
+			}
+		})
+	}
+	if v := LookupTag(panicValue(42), "panic_value"); v != 42 {
+		t.Fatalf("want panic_value 42 got %v", v)
+	}
+}
+
+func TestFromPanicNil(t *testing.T) {
+	noPanic := func() (c Chain) {
+		defer func() {
+			c = FromPanic(recover())
+		}()
+		return nil
+	}
+	if c := noPanic(); c != nil {
+		t.Fatalf("want nil Chain when not panicking got %s", c)
+	}
+}
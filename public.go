@@ -0,0 +1,105 @@
+package errors
+
+import unsafeext "github.com/go-playground/pkg/v5/unsafe"
+
+var defaultPublicMessage = "An internal error occurred."
+
+// Public is a message intended for end users, such as API clients, kept separate from the developer oriented Prefix
+// and error text of a Link, which are never shown to them.
+type Public struct {
+
+	// Message is the user facing message, which may contain {key} placeholders interpolated with the Params, see NewT.
+	Message string
+
+	// Key is the optional i18n message key used to look up a translated message, if set
+	Key string
+
+	// Params are the optional parameters of the translated message and placeholders of the Message
+	Params []Tag
+}
+
+// String returns the Message interpolated with the Params. The placeholders of sensitive Params are omitted, as even
+// their redacted form is not meaningful to end users.
+func (p Public) String() string {
+	return unsafeext.BytesToString(appendTemplate(make([]byte, 0, len(p.Message)), p.Message, p.Params, true))
+}
+
+// RegisterDefaultPublicMessage sets the message returned by PublicMessage for errors without a public message, the
+// default is "An internal error occurred.".
+//
+// NOTE: this is not concurrency safe and should be called during initialization.
+func RegisterDefaultPublicMessage(msg string) {
+	defaultPublicMessage = msg
+}
+
+// WithPublicMessage sets the user facing message of the Link, see PublicMessage. The Error output is unchanged.
+//
+//	return errors.Wrap(err, "charging card").WithPublicMessage("We couldn't process your payment")
+func (c Chain) WithPublicMessage(msg string) Chain {
+	c, l := c.mutable()
+	p := new(Public)
+	if l.extension().public != nil {
+		*p = *l.ext.public
+	}
+	p.Message = msg
+	l.extend().public = p
+	return c
+}
+
+// WithPublicKey sets the i18n message key, and its parameters, of the Links user facing message, the message set
+// using WithPublicMessage is kept as the untranslated fallback.
+//
+//	return errors.Wrap(err, "charging card").
+//		WithPublicMessage("We couldn't charge {amount}").
+//		WithPublicKey("payment.failed", errors.T("amount", amount))
+func (c Chain) WithPublicKey(key string, params ...Tag) Chain {
+	c, l := c.mutable()
+	p := new(Public)
+	if l.extension().public != nil {
+		p.Message = l.ext.public.Message
+	}
+	p.Key = key
+	p.Params = append([]Tag(nil), params...)
+	l.extend().public = p
+	return c
+}
+
+// Public returns the user facing message of the Link, if set, see WithPublicMessage.
+func (l *Link) Public() (Public, bool) {
+	p := l.extension().public
+	if p == nil {
+		return Public{}, false
+	}
+	return *p, true
+}
+
+// LookupPublic recursively searches for the outermost user facing message of the error, see WithPublicMessage. Its
+// Message is empty when only WithPublicKey was used, see PublicMessage for the text to display.
+func LookupPublic(err error) (Public, bool) {
+	var p *Public
+	walkLinks(err, true, func(l *Link) bool {
+		p = l.extension().public
+		return p == nil
+	})
+	if p == nil {
+		return Public{}, false
+	}
+	return *p, true
+}
+
+// PublicMessage returns the outermost non-empty user facing message of the error, interpolated with its Params, or the
+// default message when there is none, see RegisterDefaultPublicMessage. It never contains the internal error text,
+// making it safe to return to end users eg. in a HTTP response.
+func PublicMessage(err error) string {
+	var p *Public
+	walkLinks(err, true, func(l *Link) bool {
+		if lp := l.extension().public; lp != nil && lp.Message != "" {
+			p = lp
+		}
+		return p == nil
+	})
+	if p == nil {
+		return defaultPublicMessage
+	}
+	return p.String()
+}
@@ -0,0 +1,69 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPublicMessage(t *testing.T) {
+	inner := Wrap(io.EOF, "reading card token").WithPublicMessage("Your card could not be read")
+	err := fmt.Errorf("std: %w", Wrap(inner, "charging card for order 123").WithPublicMessage("We couldn't process your payment"))
+
+	if msg := PublicMessage(err); msg != "We couldn't process your payment" {
+		t.Fatalf("want outermost public message got %q", msg)
+	}
+	if msg := PublicMessage(inner); msg != "Your card could not be read" {
+		t.Fatalf("want inner public message got %q", msg)
+	}
+	if strings.Contains(err.Error(), "payment") {
+		t.Fatalf("want Error output unchanged got %s", err)
+	}
+	if msg := PublicMessage(Wrap(io.EOF, "internal detail")); msg != defaultPublicMessage {
+		t.Fatalf("want default public message got %q", msg)
+	}
+
+	old := defaultPublicMessage
+	defer func() { defaultPublicMessage = old }()
+	RegisterDefaultPublicMessage("Something went wrong")
+	if msg := PublicMessage(io.EOF); msg != "Something went wrong" {
+		t.Fatalf("want registered default public message got %q", msg)
+	}
+}
+
+func TestPublicKey(t *testing.T) {
+	base := Wrap(io.EOF, "charging card").WithPublicMessage("We couldn't charge {amount}")
+	err := base.WithPublicKey("payment.failed", T("amount", "$10"), T("card", Secret("4111")))
+
+	p, ok := LookupPublic(err)
+	if !ok || p.Key != "payment.failed" || p.Message != "We couldn't charge {amount}" {
+		t.Fatalf("want public message with key got %+v", p)
+	}
+	if !reflect.DeepEqual(p.Params[0], T("amount", "$10")) {
+		t.Fatalf("want params got %v", p.Params)
+	}
+	if msg := PublicMessage(err); msg != "We couldn't charge $10" {
+		t.Fatalf("want interpolated public message got %q", msg)
+	}
+	secret := base.WithPublicMessage("We couldn't charge {amount} to card {card}").
+		WithPublicKey("payment.failed", T("amount", "$10"), T("card", Secret("4111")))
+	if msg := PublicMessage(secret); msg != "We couldn't charge $10 to card " {
+		t.Fatalf("want sensitive params omitted got %q", msg)
+	}
+
+	keyOnly := Wrap(Wrap(io.EOF, "inner").WithPublicMessage("Inner message"), "outer").WithPublicKey("outer.key")
+	if msg := PublicMessage(keyOnly); msg != "Inner message" {
+		t.Fatalf("want inner public message when the outer has only a key got %q", msg)
+	}
+	if p, _ := LookupPublic(keyOnly); p.Key != "outer.key" {
+		t.Fatalf("want outermost public key got %+v", p)
+	}
+	if p, _ := base.current().Public(); p.Key != "" {
+		t.Fatal("want original Chain unmodified")
+	}
+	if _, ok := New("test").current().Public(); ok {
+		t.Fatal("want no public message")
+	}
+}
@@ -0,0 +1,126 @@
+package errors
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+)
+
+var (
+	sensitiveKeys map[string]struct{}
+	scrubbers     []*regexp.Regexp
+	redactionKey  []byte
+)
+
+// RegisterSensitiveKeys marks Tags with the provided keys as sensitive; their values will be redacted in all output.
+// NOTE: this is not concurrency safe and should be called during initialization.
+func RegisterSensitiveKeys(keys ...string) {
+	if sensitiveKeys == nil {
+		sensitiveKeys = make(map[string]struct{}, len(keys))
+	}
+	for _, k := range keys {
+		sensitiveKeys[k] = struct{}{}
+	}
+}
+
+// RegisterScrubber adds a regular expression whose matches are redacted from prefixes and root error messages.
+// NOTE: this is not concurrency safe and should be called during initialization.
+func RegisterScrubber(re *regexp.Regexp) {
+	scrubbers = append(scrubbers, re)
+}
+
+// RegisterRedactionKey sets a secret key used to HMAC redacted values, so the hashes in redacted output cannot be
+// reversed by hashing guessed values. By default an unkeyed SHA-256 is used.
+// NOTE: this is not concurrency safe and should be called during initialization.
+func RegisterRedactionKey(key []byte) {
+	redactionKey = key
+}
+
+// Secret wraps a Tag value marking it as sensitive.
+//
+// The value is printed, marshalled and logged in its redacted form, which contains a stable hash of the value
+// so that occurrences can still be correlated. Use SecretValue.Unredacted to access the original value.
+func Secret(value any) SecretValue {
+	if s, ok := value.(SecretValue); ok {
+		return s
+	}
+	return SecretValue{value: value}
+}
+
+// SecretValue contains a sensitive value, see Secret.
+type SecretValue struct {
+	value any
+}
+
+// Unredacted returns the original value and should only be used by trusted code.
+func (s SecretValue) Unredacted() any {
+	return s.value
+}
+
+// String returns the redacted form of the value.
+func (s SecretValue) String() string {
+	return redact(fmt.Sprint(s.value))
+}
+
+// Format ensures the value is redacted regardless of the fmt verb used.
+func (s SecretValue) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, s.String())
+}
+
+// MarshalText returns the redacted form of the value.
+func (s SecretValue) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// Sensitive returns a copy of the Tag with its value marked as sensitive, see Secret.
+func (t Tag) Sensitive() Tag {
+	t.Value = Secret(t.Value)
+	return t
+}
+
+// MarshalJSON marshals the Tag, redacting its value if sensitive.
+func (t Tag) MarshalJSON() ([]byte, error) {
+	type tag Tag
+	return json.Marshal(tag{Key: t.Key, Value: t.RedactedValue()})
+}
+
+// RedactedValue returns the Tags value, or the value as a SecretValue if the Tag is sensitive. This should be used in
+// preference to Value when outputting a Tag.
+func (t Tag) RedactedValue() any {
+	if _, ok := t.Value.(SecretValue); ok {
+		return t.Value
+	}
+	if _, ok := sensitiveKeys[t.Key]; ok {
+		return SecretValue{value: t.Value}
+	}
+	return t.Value
+}
+
+// scrub redacts all registered scrubber matches from the provided string.
+func scrub(s string) string {
+	for _, re := range scrubbers {
+		s = re.ReplaceAllStringFunc(s, redact)
+	}
+	return s
+}
+
+func redact(s string) string {
+	var sum []byte
+	if redactionKey != nil {
+		h := hmac.New(sha256.New, redactionKey)
+		_, _ = io.WriteString(h, s)
+		sum = h.Sum(nil)
+	} else {
+		h := sha256.Sum256([]byte(s))
+		sum = h[:]
+	}
+	b := make([]byte, 27)
+	copy(b, "[REDACTED:")
+	hex.Encode(b[10:26], sum[:8])
+	b[26] = ']'
+	return string(b)
+}
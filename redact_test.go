@@ -0,0 +1,62 @@
+package errors
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestRedaction(t *testing.T) {
+	RegisterSensitiveKeys("test_redaction_token")
+	RegisterScrubber(regexp.MustCompile(`test-redaction-[0-9]+`))
+
+	err := Wrap(io.EOF, "lookup test-redaction-1234 failed").AddTags(
+		T("email", "joeybloggs@example.com").Sensitive(),
+		T("test_redaction_token", "abc"),
+		T("visible", "value"),
+	)
+
+	tests := []struct {
+		name   string
+		output string
+	}{
+		{name: "error", output: err.Error()},
+		{name: "link error", output: err.current().Error()},
+		{name: "sprintf", output: fmt.Sprintf("%v %+v %#v", err, err.current().Tags[0].Value, err.current().Tags[0].Value)},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			for _, leaked := range []string{"joeybloggs", "abc", "1234"} {
+				if strings.Contains(tc.output, leaked) {
+					t.Fatalf("output leaked %q: %s", leaked, tc.output)
+				}
+			}
+		})
+	}
+	if !strings.Contains(err.Error(), "visible=value") {
+		t.Fatalf("want visible tag got %s", err.Error())
+	}
+
+	b, e := json.Marshal(err.current().Tags)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if strings.Contains(string(b), "joeybloggs") || strings.Contains(string(b), "abc") {
+		t.Fatalf("json leaked sensitive value: %s", b)
+	}
+
+	// stable hash for correlation
+	if Secret("abc").String() != fmt.Sprint(LookupTag(err, "test_redaction_token")) {
+		t.Fatalf("want stable redacted output got %s and %v", Secret("abc"), LookupTag(err, "test_redaction_token"))
+	}
+	if v := LookupTagUnredacted(err, "test_redaction_token"); v != "abc" {
+		t.Fatalf("want abc got %v", v)
+	}
+	if v := LookupTagUnredacted(err, "email"); v != "joeybloggs@example.com" {
+		t.Fatalf("want joeybloggs@example.com got %v", v)
+	}
+}
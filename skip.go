@@ -0,0 +1,139 @@
+package errors
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+
+	"github.com/go-playground/errors/v6/internal/names"
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+var (
+	skipMu        sync.RWMutex
+	skipFunctions map[string]struct{}
+	skipPackages  map[string]struct{}
+	skipping      int32
+)
+
+// MarkHelper marks the calling function as an error helper, like testing.T.Helper, so that errors created or wrapped
+// within it, directly or by other marked functions, have the source of the first unmarked caller. This removes the need
+// to count frames using WrapSkipFrames.
+//
+//	func wrapQuery(err error, query string) error {
+//		errors.MarkHelper()
+//		return errors.Wrap(err, "query failed").AddTag("query", query)
+//	}
+func MarkHelper() {
+	var pc [1]uintptr
+	runtime.Callers(2, pc[:])
+	frame, _ := runtime.CallersFrames(pc[:]).Next()
+
+	skipMu.RLock()
+	_, ok := skipFunctions[frame.Function]
+	skipMu.RUnlock()
+	if !ok {
+		RegisterSkipFunctions(frame.Function)
+	}
+}
+
+// RegisterSkipFunctions marks the functions, by their fully qualified name eg. `github.com/org/db.(*DB).wrapErr`, as
+// error helpers, see MarkHelper. It returns a function restoring the previously marked functions, including any marked
+// since using MarkHelper, eg. for use in tests.
+func RegisterSkipFunctions(fns ...string) (restore func()) {
+	skipMu.Lock()
+	defer skipMu.Unlock()
+	prev := skipFunctions
+	skipFunctions = withSkipped(prev, fns)
+	updateSkipping()
+	return func() {
+		skipMu.Lock()
+		defer skipMu.Unlock()
+		skipFunctions = prev
+		updateSkipping()
+	}
+}
+
+// RegisterSkipPackages marks all functions in the packages, by their import path eg. `github.com/org/db`, as error
+// helpers, see MarkHelper. It returns a function restoring the previously marked packages, eg. for use in tests.
+func RegisterSkipPackages(pkgs ...string) (restore func()) {
+	skipMu.Lock()
+	defer skipMu.Unlock()
+	prev := skipPackages
+	skipPackages = withSkipped(prev, pkgs)
+	updateSkipping()
+	return func() {
+		skipMu.Lock()
+		defer skipMu.Unlock()
+		skipPackages = prev
+		updateSkipping()
+	}
+}
+
+// withSkipped returns a copy of the skipped names with the names added, leaving the original to be restored.
+func withSkipped(skipped map[string]struct{}, add []string) map[string]struct{} {
+	m := make(map[string]struct{}, len(skipped)+len(add))
+	for name := range skipped {
+		m[name] = struct{}{}
+	}
+	for _, name := range add {
+		m[name] = struct{}{}
+	}
+	return m
+}
+
+// updateSkipping enables the slow path of stackLevel only while functions, packages or FrameFilters are registered so
+// that capturing sources is as cheap as runtimeext.StackLevel otherwise, skipMu must be held.
+func updateSkipping() {
+	var v int32
+	if len(skipFunctions) > 0 || len(skipPackages) > 0 || len(frameFilters) > 0 {
+		v = 1
+	}
+	atomic.StoreInt32(&skipping, v)
+}
+
+// stackLevel returns the frame skip frames above the caller of stackLevel, skipping any frames of marked functions and
+// those matching a FrameFilter, along with the first filtered frame, if any.
+func stackLevel(skip int) (runtimeext.Frame, *runtimeext.Frame) {
+	if atomic.LoadInt32(&skipping) == 0 {
+		return runtimeext.StackLevel(skip + 1), nil
+	}
+	var pcs [32]uintptr
+	n := runtime.Callers(skip+2, pcs[:])
+	frames := runtime.CallersFrames(pcs[:n])
+	first, more := frames.Next()
+
+	skipMu.RLock()
+	defer skipMu.RUnlock()
+	var origin *runtimeext.Frame
+	for f := first; ; f, more = frames.Next() {
+		if !isSkipped(f.Function) {
+			if !filtered(f) {
+				return runtimeext.Frame{Frame: f}, origin
+			}
+			if origin == nil {
+				origin = &runtimeext.Frame{Frame: f}
+			}
+		}
+		if !more {
+			break
+		}
+	}
+	// no application frame was found
+	if origin != nil {
+		return *origin, nil
+	}
+	return runtimeext.Frame{Frame: first}, nil
+}
+
+// isSkipped returns if the function is marked, skipMu must be held.
+func isSkipped(fn string) bool {
+	if _, ok := skipFunctions[fn]; ok {
+		return true
+	}
+	if len(skipPackages) > 0 {
+		_, ok := skipPackages[names.FuncPackage(fn)]
+		return ok
+	}
+	return false
+}
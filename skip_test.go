@@ -0,0 +1,107 @@
+package errors
+
+import (
+	"io"
+	"sync/atomic"
+	"testing"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+func skipNew() Chain {
+	MarkHelper()
+	return New("new")
+}
+
+func skipWrap(err error) Chain {
+	MarkHelper()
+	return Wrap(err, "wrap")
+}
+
+func skipNested(err error) Chain {
+	MarkHelper()
+	return skipWrap(err).Wrap("nested")
+}
+
+func skipAnnotate() (err error) {
+	MarkHelper()
+	defer Annotate(&err, "annotate")
+	return io.EOF
+}
+
+func skipRegistered(err error) Chain {
+	return Wrap(err, "registered")
+}
+
+func unmarked(err error) Chain {
+	return Wrap(err, "unmarked")
+}
+
+func TestMarkHelper(t *testing.T) {
+	// also restores the functions marked by MarkHelper
+	defer RegisterSkipFunctions("github.com/go-playground/errors/v6.skipRegistered")()
+
+	check := func(name string, err error, source runtimeext.Frame) {
+		t.Helper()
+		for _, l := range err.(Chain) {
+			if l.Source.Function() != source.Function() || l.Source.Line() != source.Line() {
+				t.Errorf("%s: want source %s:%d got %s:%d", name, source.Function(), source.Line(),
+					l.Source.Function(), l.Source.Line())
+			}
+		}
+	}
+
+	c, source := skipNew(), runtimeext.Stack()
+	check("new", c, source)
+	c, source = skipWrap(io.EOF), runtimeext.Stack()
+	check("wrap", c, source)
+	c, source = skipNested(io.EOF), runtimeext.Stack()
+	check("chain wrap", c, source)
+	c, source = skipRegistered(io.EOF), runtimeext.Stack()
+	check("registered", c, source)
+	err, source := skipAnnotate(), runtimeext.Stack()
+	check("annotate", err, source)
+
+	if c := unmarked(io.EOF); c[1].Source.Function() != "unmarked" {
+		t.Fatalf("want unmarked source got %s", c[1].Source.Function())
+	}
+}
+
+func TestRegisterSkipPackages(t *testing.T) {
+	restore := RegisterSkipPackages("example.com/skipped")
+	defer restore()
+
+	skipMu.RLock()
+	skipped := isSkipped("example.com/skipped.(*DB).Load") && !isSkipped("example.com/skipped/sub.Load")
+	skipMu.RUnlock()
+	if !skipped {
+		t.Fatal("want only functions in the package skipped")
+	}
+
+	restore()
+	skipMu.RLock()
+	defer skipMu.RUnlock()
+	if isSkipped("example.com/skipped.(*DB).Load") {
+		t.Fatal("want package no longer skipped once restored")
+	}
+}
+
+func TestStackLevelUnskipped(t *testing.T) {
+	if atomic.LoadInt32(&skipping) != 0 {
+		t.Fatal("want no functions, packages or FrameFilters registered by other tests")
+	}
+	c, source := unmarked(io.EOF), runtimeext.Stack()
+	if c[1].Source.Function() != "unmarked" {
+		t.Fatalf("want unmarked source got %s", c[1].Source.Function())
+	}
+
+	restore := RegisterSkipFunctions("github.com/go-playground/errors/v6.unmarked")
+	c = unmarked(io.EOF)
+	restore()
+	if c[1].Source.Function() != source.Function() {
+		t.Fatalf("want caller source got %s", c[1].Source.Function())
+	}
+	if atomic.LoadInt32(&skipping) != 0 {
+		t.Fatal("want the unskipped path once restored")
+	}
+}
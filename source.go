@@ -0,0 +1,46 @@
+package errors
+
+import (
+	"sync/atomic"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+var sourceProvider atomic.Value
+
+func init() {
+	sourceProvider.Store(sourceProviderHolder{p: runtimeSource{}})
+}
+
+// sourceProviderHolder allows storing any SourceProvider implementation in an atomic.Value.
+type sourceProviderHolder struct {
+	p SourceProvider
+}
+
+// SourceProvider provides the source of each Link when creating or wrapping errors.
+type SourceProvider interface {
+
+	// Source returns the frame skip frames above the caller of Source.
+	Source(skip int) runtimeext.Frame
+}
+
+// runtimeSource is the default SourceProvider which obtains the source from the stack trace, skipping marked helper
+// functions and filtered frames, see MarkHelper and FrameFilter.
+type runtimeSource struct{}
+
+// Source returns the frame skip frames above the caller of Source.
+func (runtimeSource) Source(skip int) runtimeext.Frame {
+	f, _ := stackLevel(skip + 1)
+	return f
+}
+
+// RegisterSourceProvider sets the SourceProvider used by all Wrappers that do not have their own and returns a
+// function which restores the previous SourceProvider eg. t.Cleanup(errors.RegisterSourceProvider(p)).
+//
+// NOTE: as this is global it affects tests running in parallel, use a Wrapper when that is a concern.
+func RegisterSourceProvider(p SourceProvider) (restore func()) {
+	prev := sourceProvider.Swap(sourceProviderHolder{p: p})
+	return func() {
+		sourceProvider.Store(prev)
+	}
+}
@@ -0,0 +1,48 @@
+package errors
+
+import (
+	"io"
+	"runtime"
+	"testing"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+type fixedSource struct {
+	line int
+}
+
+func (s *fixedSource) Source(int) runtimeext.Frame {
+	s.line++
+	return runtimeext.Frame{Frame: runtime.Frame{Function: "example.com/store.Load", File: "/src/store/db.go", Line: s.line}}
+}
+
+func TestWrapperSourceProvider(t *testing.T) {
+	w := &Wrapper{SourceProvider: &fixedSource{}}
+
+	err := w.New("base")
+	err = Wrap(err, "package wrap")
+	err = err.Wrap("chain wrap")
+
+	want := "source=example.com/store/db.go:1:Load error=base\n" +
+		"source=example.com/store/db.go:2:Load error=package wrap\n" +
+		"source=example.com/store/db.go:3:Load error=chain wrap"
+	if err.Error() != want {
+		t.Fatalf("want:\n%s\ngot:\n%s", want, err.Error())
+	}
+
+	if Fingerprint(w.Wrap(io.EOF, "a")) != Fingerprint(w.Wrap(io.EOF, "b")) {
+		t.Fatal("want identical fingerprints from identical sources")
+	}
+
+	// the registered provider is used by errors not created by the Wrapper
+	restore := RegisterSourceProvider(&fixedSource{line: 41})
+	err = New("base")
+	restore()
+	if want = "source=example.com/store/db.go:42:Load error=base"; err.Error() != want {
+		t.Fatalf("want %s got %s", want, err.Error())
+	}
+	if New("base").current().Source.Function() != "TestWrapperSourceProvider" {
+		t.Fatal("want registered SourceProvider to be restored")
+	}
+}
@@ -0,0 +1,148 @@
+package errors
+
+import (
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/errors/v6/internal/names"
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+// SourcePathPolicy controls how the path of a Link's source is formatted.
+type SourcePathPolicy uint8
+
+const (
+	// SourcePathPackage formats the path as the functions package path followed by the file name
+	// eg. github.com/org/module/internal/store/db.go:42:Load, this is the default.
+	SourcePathPackage SourcePathPolicy = iota
+
+	// SourcePathTrimModule formats the path as SourcePathPackage with the module prefix trimmed
+	// eg. internal/store/db.go:42:Load, see RegisterModulePrefix.
+	SourcePathTrimModule
+
+	// SourcePathModuleRelative formats the path relative to the root of the module containing the source, which
+	// may be a dependency, eg. internal/store/db.go:42:Load
+	SourcePathModuleRelative
+
+	// SourcePathBase formats the path as the file name only eg. db.go:42:Load
+	SourcePathBase
+
+	// SourcePathFull formats the path as the full file path as recorded by the compiler
+	// eg. /home/joeybloggs/module/internal/store/db.go:42:Load
+	SourcePathFull
+)
+
+var (
+	sourcePathPolicy SourcePathPolicy
+	modulePrefix     string
+	modulePrefixSet  bool
+	modulesOnce      sync.Once
+	modules          []string
+	mainModule       string
+)
+
+// RegisterSourcePathPolicy sets the policy used when formatting the path of a Link's source.
+func RegisterSourcePathPolicy(policy SourcePathPolicy) {
+	sourcePathPolicy = policy
+}
+
+// RegisterModulePrefix sets the prefix trimmed from package paths when using SourcePathTrimModule.
+// By default, this is the main module path detected using debug.ReadBuildInfo.
+func RegisterModulePrefix(prefix string) {
+	modulePrefix = prefix
+	modulePrefixSet = true
+}
+
+func loadModules() {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return
+	}
+	mainModule = bi.Main.Path
+	if mainModule != "" {
+		modules = append(modules, mainModule)
+	}
+	for _, d := range bi.Deps {
+		modules = append(modules, d.Path)
+	}
+	// longest first so nested modules are matched before their parent
+	sort.Slice(modules, func(i, j int) bool {
+		return len(modules[i]) > len(modules[j])
+	})
+}
+
+// AppendSource appends the frame formatted as path:line:function according to the policy.
+func AppendSource(b []byte, f runtimeext.Frame, policy SourcePathPolicy) []byte {
+	var funcName string
+	idx := strings.LastIndexByte(f.Frame.Function, '.')
+
+	switch {
+	case policy == SourcePathFull:
+		b = append(b, f.Frame.File...)
+	case policy == SourcePathBase || idx == -1:
+		b = append(b, f.File()...)
+	default:
+		b = appendPackagePath(b, f, policy)
+	}
+	if idx != -1 {
+		funcName = f.Frame.Function[idx+1:]
+	}
+
+	b = append(b, ':')
+	b = strconv.AppendInt(b, int64(f.Line()), 10)
+	if funcName != "" {
+		b = append(b, ':')
+		b = append(b, funcName...)
+	}
+	return b
+}
+
+func appendPackagePath(b []byte, f runtimeext.Frame, policy SourcePathPolicy) []byte {
+	pkg := names.FuncPackage(f.Frame.Function)
+
+	switch policy {
+	case SourcePathTrimModule:
+		prefix := modulePrefix
+		if !modulePrefixSet {
+			modulesOnce.Do(loadModules)
+			prefix = mainModule
+		}
+		if prefix != "" && (pkg == prefix || strings.HasPrefix(pkg, prefix+"/")) {
+			pkg = strings.TrimPrefix(pkg[len(prefix):], "/")
+		}
+
+	case SourcePathModuleRelative:
+		modulesOnce.Do(loadModules)
+		rel, ok := moduleRelative(pkg)
+		if !ok {
+			// package main and -trimpath builds of the main module have file paths prefixed with the module path.
+			if mainModule != "" && strings.HasPrefix(f.Frame.File, mainModule+"/") {
+				return append(b, f.Frame.File[len(mainModule)+1:]...)
+			}
+			return append(b, f.File()...)
+		}
+		pkg = rel
+	}
+
+	if pkg != "" {
+		b = append(b, pkg...)
+		b = append(b, '/')
+	}
+	return append(b, f.File()...)
+}
+
+// moduleRelative returns the package path relative to the root of the module containing it.
+func moduleRelative(pkg string) (string, bool) {
+	for _, m := range modules {
+		if pkg == m {
+			return "", true
+		}
+		if strings.HasPrefix(pkg, m) && pkg[len(m)] == '/' {
+			return pkg[len(m)+1:], true
+		}
+	}
+	return "", false
+}
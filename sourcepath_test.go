@@ -0,0 +1,80 @@
+package errors
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+func TestAppendSource(t *testing.T) {
+	frame := func(function, file string) runtimeext.Frame {
+		return runtimeext.Frame{Frame: runtime.Frame{Function: function, File: file, Line: 42}}
+	}
+	local := frame("github.com/go-playground/errors/v6/internal/store.(*DB).Load", "/home/joeybloggs/errors/internal/store/db.go")
+	dep := frame("github.com/go-playground/pkg/v5/runtime.StackLevel", "/go/pkg/mod/github.com/go-playground/pkg/v5@v5.21.3/runtime/stack.go")
+	trimmed := frame("main.main", "github.com/go-playground/errors/v6/cmd/tool/main.go")
+
+	tests := []struct {
+		name   string
+		frame  runtimeext.Frame
+		policy SourcePathPolicy
+		want   string
+	}{
+		{name: "package", frame: local, policy: SourcePathPackage, want: "github.com/go-playground/errors/v6/internal/store/db.go:42:Load"},
+		{name: "trim module", frame: local, policy: SourcePathTrimModule, want: "internal/store/db.go:42:Load"},
+		{name: "module relative", frame: local, policy: SourcePathModuleRelative, want: "internal/store/db.go:42:Load"},
+		{name: "base", frame: local, policy: SourcePathBase, want: "db.go:42:Load"},
+		{name: "full", frame: local, policy: SourcePathFull, want: "/home/joeybloggs/errors/internal/store/db.go:42:Load"},
+		{name: "dependency trim module", frame: dep, policy: SourcePathTrimModule, want: "github.com/go-playground/pkg/v5/runtime/stack.go:42:StackLevel"},
+		{name: "dependency module relative", frame: dep, policy: SourcePathModuleRelative, want: "runtime/stack.go:42:StackLevel"},
+		{name: "trimpath main package", frame: trimmed, policy: SourcePathPackage, want: "main/main.go:42:main"},
+		{name: "trimpath main module relative", frame: trimmed, policy: SourcePathModuleRelative, want: "cmd/tool/main.go:42:main"},
+	}
+	for _, tc := range tests {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			if got := string(AppendSource(nil, tc.frame, tc.policy)); got != tc.want {
+				t.Fatalf("want %s got %s", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestRegisterSourcePathPolicy(t *testing.T) {
+	defer RegisterSourcePathPolicy(SourcePathPackage)
+	RegisterSourcePathPolicy(SourcePathTrimModule)
+
+	err, source := New("base"), runtimeext.Stack()
+	want := fmt.Sprintf("source=sourcepath_test.go:%d:TestRegisterSourcePathPolicy error=base", source.Line())
+	if err.Error() != want {
+		t.Fatalf("want %s got %s", want, err.Error())
+	}
+}
+
+func TestRegisterModulePrefix(t *testing.T) {
+	defer func(prefix string, set bool) {
+		modulePrefix, modulePrefixSet = prefix, set
+	}(modulePrefix, modulePrefixSet)
+
+	f := runtimeext.Frame{Frame: runtime.Frame{
+		Function: "github.com/go-playground/errors/v6/internal/store.Load",
+		File:     "/home/joeybloggs/errors/internal/store/db.go",
+		Line:     42,
+	}}
+	tests := []struct {
+		prefix string
+		want   string
+	}{
+		{prefix: "github.com/go-playground/errors/v6", want: "internal/store/db.go:42:Load"},
+		{prefix: "github.com/go-playground/errors/v6/internal/store", want: "db.go:42:Load"},
+		{prefix: "github.com/go-playground/err", want: "github.com/go-playground/errors/v6/internal/store/db.go:42:Load"},
+	}
+	for _, tc := range tests {
+		RegisterModulePrefix(tc.prefix)
+		if got := string(AppendSource(nil, f, SourcePathTrimModule)); got != tc.want {
+			t.Errorf("prefix %s want %s got %s", tc.prefix, tc.want, got)
+		}
+	}
+}
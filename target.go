@@ -0,0 +1,87 @@
+package errors
+
+// TypeTarget is an error target, for use with Is, which matches any Link carrying the type.
+//
+//	if errors.Is(err, errors.TypeTarget("NotFound")) {
+//		...
+//	}
+type TypeTarget string
+
+// Error returns the type.
+func (t TypeTarget) Error() string {
+	return string(t)
+}
+
+// LinkTarget is an error target, for use with As, which matches the first Link carrying the Type and/or Tag with the
+// TagKey, searching from the outermost Link, and sets Link to it.
+//
+//	t := errors.LinkByTag("user_id")
+//	if errors.As(err, t) {
+//		fmt.Println(t.Link.Source)
+//	}
+type LinkTarget struct {
+
+	// Type is the type the Link must carry, if set
+	Type string
+
+	// TagKey is the key of the Tag the Link must carry, if set
+	TagKey string
+
+	// Link is the matching Link set by As
+	Link *Link
+}
+
+// LinkByType returns a LinkTarget matching the first Link carrying the type.
+func LinkByType(typ string) *LinkTarget {
+	return &LinkTarget{Type: typ}
+}
+
+// LinkByTag returns a LinkTarget matching the first Link carrying a Tag with the key.
+func LinkByTag(key string) *LinkTarget {
+	return &LinkTarget{TagKey: key}
+}
+
+// Error returns a description of the target.
+func (t LinkTarget) Error() string {
+	s := "link"
+	if t.Type != "" {
+		s += " type=" + t.Type
+	}
+	if t.TagKey != "" {
+		s += " tag=" + t.TagKey
+	}
+	return s
+}
+
+// matches returns if the Link meets all of the targets criteria.
+func (t *LinkTarget) matches(l *Link) bool {
+	if t.Type == "" && t.TagKey == "" {
+		return false
+	}
+	if t.Type != "" && !l.hasType(t.Type) {
+		return false
+	}
+	if t.TagKey != "" && !l.hasTag(t.TagKey) {
+		return false
+	}
+	return true
+}
+
+// hasType returns if the Link carries the type, resolving Kinds through their hierarchy.
+func (l *Link) hasType(typ string) bool {
+	for _, t := range l.Types {
+		if kindIs(t, typ) {
+			return true
+		}
+	}
+	return false
+}
+
+func (l *Link) hasTag(key string) bool {
+	for _, tag := range l.Tags {
+		if tag.Key == key {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"fmt"
+	"io"
+	"testing"
+)
+
+func TestTypeTarget(t *testing.T) {
+	err := Wrap(io.EOF, "inner").AddTypes("NotFound")
+	err = Wrap(fmt.Errorf("std: %w", err), "outer").AddTypes("Permanent")
+
+	tests := []struct {
+		err      error
+		target   TypeTarget
+		expected bool
+	}{
+		{err: err, target: "NotFound", expected: true},
+		{err: err, target: "Permanent", expected: true},
+		{err: err, target: "Transient", expected: false},
+		{err: fmt.Errorf("wrapped: %w", err), target: "NotFound", expected: true},
+		{err: io.EOF, target: "NotFound", expected: false},
+	}
+
+	for i, tt := range tests {
+		if actual := Is(tt.err, tt.target); actual != tt.expected {
+			t.Errorf("#%d want %t got %t", i, tt.expected, actual)
+		}
+	}
+
+	if !Is(err, io.EOF) {
+		t.Fatal("want std targets to match")
+	}
+}
+
+func TestLinkTarget(t *testing.T) {
+	inner := Wrap(io.EOF, "inner").AddTypes("NotFound").AddTag("id", 1)
+	err := fmt.Errorf("wrapped: %w", Wrap(fmt.Errorf("std: %w", inner), "outer").AddTag("id", 2))
+
+	target := LinkByType("NotFound")
+	if !As(err, target) {
+		t.Fatal("want type match")
+	}
+	if target.Link.Prefix != "inner" {
+		t.Fatalf("want inner Link got %q", target.Link.Prefix)
+	}
+
+	target = LinkByTag("id")
+	if !As(err, target) {
+		t.Fatal("want tag match")
+	}
+	if target.Link.Prefix != "outer" {
+		t.Fatalf("want outermost Link got %q", target.Link.Prefix)
+	}
+
+	target = &LinkTarget{Type: "NotFound", TagKey: "id"}
+	if !As(err, target) || target.Link.Prefix != "inner" {
+		t.Fatal("want Link matching both type and tag")
+	}
+
+	for _, target = range []*LinkTarget{LinkByType("Transient"), LinkByTag("missing"), {}} {
+		if As(err, target) {
+			t.Errorf("want no match for %s", target)
+		}
+	}
+}
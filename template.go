@@ -0,0 +1,171 @@
+package errors
+
+import (
+	stderrors "errors"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/errors/v6/internal/names"
+	unsafeext "github.com/go-playground/pkg/v5/unsafe"
+)
+
+// NewT creates an error whose text is the template with each {key} placeholder replaced by the value of the Tag with
+// that key, and adds the Tags to its Link, eg.
+//
+//	errors.NewT("user {user_id} not found in {table}", errors.T("user_id", id), errors.T("table", "users"))
+//
+// Sensitive values are redacted, {{ and }} are replaced by literal braces and placeholders without a Tag are left as
+// is, see CheckTemplate. The template is kept for grouping, see Link.Template.
+func NewT(template string, tags ...Tag) Chain {
+	return std.newT(template, tags, 3)
+}
+
+// WrapT is the same as Wrap using the template, interpolated with the Tags, as the prefix, see NewT.
+func WrapT(err error, template string, tags ...Tag) Chain {
+	return std.wrapT(err, template, tags, 3)
+}
+
+// NewT is the same as NewT using the Wrapper.
+func (w *Wrapper) NewT(template string, tags ...Tag) Chain {
+	return w.newT(template, tags, 3)
+}
+
+// WrapT is the same as WrapT using the Wrapper.
+func (w *Wrapper) WrapT(err error, template string, tags ...Tag) Chain {
+	return w.wrapT(err, template, tags, 3)
+}
+
+func (w *Wrapper) newT(template string, tags []Tag, skipFrames int) Chain {
+	err := stderrors.New(string(appendTemplate(nil, template, tags, false)))
+	return w.templateLink(err, "", template, tags, skipFrames+1)
+}
+
+func (w *Wrapper) wrapT(err error, template string, tags []Tag, skipFrames int) Chain {
+	if err == nil {
+		panic("errors: WrapT called with nil error")
+	}
+	return w.templateLink(err, string(appendTemplate(nil, template, tags, false)), template, tags, skipFrames+1)
+}
+
+func (w *Wrapper) templateLink(err error, prefix, template string, tags []Tag, skipFrames int) Chain {
+	l := w.resolve(err).newLink(nil, prefix, skipFrames)
+	l.Tags = append(l.Tags, tags...)
+	ext := l.extend()
+	ext.template = template
+	ext.templateTags = len(tags)
+	return wrapLink(nil, err, l)
+}
+
+// Template returns the template the Link was created with using NewT or WrapT, otherwise an empty string.
+func (l *Link) Template() string {
+	return l.extension().template
+}
+
+// CheckTemplate checks the Links template against the Tags it was created with, see CheckTemplate. It returns nil if
+// the Link was not created from a template.
+func (l *Link) CheckTemplate() error {
+	ext := l.extension()
+	if ext.template == "" {
+		return nil
+	}
+	// the Tags are exported and may have been shortened since creation
+	n := ext.templateTags
+	if n > len(l.Tags) {
+		n = len(l.Tags)
+	}
+	return CheckTemplate(ext.template, l.Tags[:n]...)
+}
+
+// CheckTemplate returns an error describing any placeholders of the template without a Tag and any Tags not used by a
+// placeholder, or nil if they match. It is intended for tests, see errorstest.TemplatesComplete.
+func CheckTemplate(template string, tags ...Tag) error {
+	var missing, unused []string
+	used := make([]bool, len(tags))
+	walkTemplate(template, func(string) {}, func(key string) {
+		if i := tagIndex(tags, key); i != -1 {
+			used[i] = true
+		} else if !names.Contains(missing, key) {
+			missing = append(missing, key)
+		}
+	})
+	for i, tag := range tags {
+		if !used[i] && tagIndex(tags, tag.Key) == i {
+			unused = append(unused, tag.Key)
+		}
+	}
+	if len(missing) == 0 && len(unused) == 0 {
+		return nil
+	}
+	b := append(make([]byte, 0, 64), "errors: template "...)
+	b = strconv.AppendQuote(b, template)
+	if len(missing) > 0 {
+		b = append(b, " missing Tags "...)
+		b = append(b, strings.Join(missing, ", ")...)
+	}
+	if len(unused) > 0 {
+		if len(missing) > 0 {
+			b = append(b, ';')
+		}
+		b = append(b, " unused Tags "...)
+		b = append(b, strings.Join(unused, ", ")...)
+	}
+	return stderrors.New(unsafeext.BytesToString(b))
+}
+
+// appendTemplate appends the template with each placeholder replaced by the redacted value of the first Tag with the
+// key. The placeholders of sensitive Tags are omitted entirely, rather than redacted, when omitSensitive is set.
+func appendTemplate(b []byte, template string, tags []Tag, omitSensitive bool) []byte {
+	walkTemplate(template, func(text string) {
+		b = append(b, text...)
+	}, func(key string) {
+		if i := tagIndex(tags, key); i != -1 {
+			v := tags[i].RedactedValue()
+			if _, ok := v.(SecretValue); !ok || !omitSensitive {
+				b = AppendTagValue(b, v)
+			}
+		} else {
+			b = append(b, '{')
+			b = append(b, key...)
+			b = append(b, '}')
+		}
+	})
+	return b
+}
+
+// walkTemplate calls text for each run of literal text and placeholder for the key of each {key} placeholder of the
+// template. {{ and }} are literal braces, as is an unterminated or empty placeholder.
+func walkTemplate(template string, text func(string), placeholder func(string)) {
+	for len(template) > 0 {
+		i := strings.IndexAny(template, "{}")
+		if i == -1 {
+			text(template)
+			return
+		}
+		if i > 0 {
+			text(template[:i])
+		}
+		template = template[i:]
+		if len(template) > 1 && template[1] == template[0] {
+			text(template[:1])
+			template = template[2:]
+			continue
+		}
+		end := strings.IndexByte(template, '}')
+		if template[0] == '}' || end < 2 || strings.IndexByte(template[1:end], '{') != -1 {
+			text(template[:1])
+			template = template[1:]
+			continue
+		}
+		placeholder(template[1:end])
+		template = template[end+1:]
+	}
+}
+
+func tagIndex(tags []Tag, key string) int {
+	for i, tag := range tags {
+		if tag.Key == key {
+			return i
+		}
+	}
+	return -1
+}
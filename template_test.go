@@ -0,0 +1,112 @@
+package errors
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestNewT(t *testing.T) {
+	err := NewT("user {user_id} not found in {table}", T("user_id", 42), T("table", "users"))
+
+	if expected := "user 42 not found in users"; err[0].Err.Error() != expected {
+		t.Fatalf("want %q got %q", expected, err[0].Err.Error())
+	}
+	if !strings.HasSuffix(err[0].Source.Function(), "TestNewT") {
+		t.Fatalf("want source TestNewT got %s", err[0].Source.Function())
+	}
+	if len(err) != 1 || err[0].Template() != "user {user_id} not found in {table}" {
+		t.Fatalf("want template on the root Link got %q", err[0].Template())
+	}
+	if LookupTag(err, "user_id") != 42 || LookupTag(err, "table") != "users" {
+		t.Fatal("want template values as Tags")
+	}
+	if err := err[0].CheckTemplate(); err != nil {
+		t.Fatalf("want complete template got %s", err)
+	}
+	if Wrap(io.EOF, "prefix")[1].Template() != "" {
+		t.Fatal("want no template")
+	}
+}
+
+func TestWrapT(t *testing.T) {
+	err := WrapT(io.EOF, "reading {file} with {password}", T("file", "a.txt"), T("password", "hunter2").Sensitive())
+	err = err.AddTag("attempt", 2)
+
+	l := err.current()
+	if strings.Contains(l.Prefix, "hunter2") || !strings.HasPrefix(l.Prefix, "reading a.txt with ") {
+		t.Fatalf("want interpolated prefix with redacted values got %q", l.Prefix)
+	}
+	if !strings.HasSuffix(l.Source.Function(), "TestWrapT") {
+		t.Fatalf("want source TestWrapT got %s", l.Source.Function())
+	}
+	if err := l.CheckTemplate(); err != nil {
+		t.Fatalf("want Tags added after creation to be ignored got %s", err)
+	}
+
+	wrapped := WrapT(err, "loading {name}", T("name", "config"))
+	if len(wrapped) != 3 || wrapped.current().Prefix != "loading config" {
+		t.Fatalf("want template Link added to the Chain got %v", wrapped)
+	}
+
+	newErr := func(template, name string) error {
+		return WrapT(io.EOF, template, T("name", name))
+	}
+	if Fingerprint(newErr("loading {name}", "a")) != Fingerprint(newErr("loading {name}", "b")) {
+		t.Fatal("want equal fingerprints for the same template with different values")
+	}
+	if Fingerprint(newErr("loading {name}", "a")) == Fingerprint(newErr("opening {name}", "a")) {
+		t.Fatal("want different fingerprints for different templates")
+	}
+}
+
+func TestCheckTemplateShortenedTags(t *testing.T) {
+	err := NewT("user {user_id} not found in {table}", T("user_id", 42), T("table", "users"))
+	err[0].Tags = err[0].Tags[:1]
+	if err := err[0].CheckTemplate(); err == nil || !strings.Contains(err.Error(), "missing Tags table") {
+		t.Fatalf("want missing Tag reported got %v", err)
+	}
+}
+
+func TestTemplateInterpolation(t *testing.T) {
+	tests := []struct {
+		template string
+		tags     []Tag
+		expected string
+	}{
+		{template: "plain", expected: "plain"},
+		{template: "{a} and {b}", tags: []Tag{T("a", 1), T("b", true)}, expected: "1 and true"},
+		{template: "{a}{a}", tags: []Tag{T("a", "x"), T("a", "y")}, expected: "xx"},
+		{template: "{{a}} {a}", tags: []Tag{T("a", 1)}, expected: "{a} 1"},
+		{template: "{missing}", expected: "{missing}"},
+		{template: "{} { {a {a{b}", tags: []Tag{T("b", 2)}, expected: "{} { {a {a2"},
+		{template: "a } b", expected: "a } b"},
+	}
+
+	for i, tt := range tests {
+		if actual := string(appendTemplate(nil, tt.template, tt.tags, false)); actual != tt.expected {
+			t.Errorf("#%d want %q got %q", i, tt.expected, actual)
+		}
+	}
+}
+
+func TestCheckTemplate(t *testing.T) {
+	tests := []struct {
+		template string
+		tags     []Tag
+		expected string
+	}{
+		{template: "{a} {b}", tags: []Tag{T("a", 1), T("b", 2)}},
+		{template: "{a} {b} {b}", tags: []Tag{T("a", 1)}, expected: `errors: template "{a} {b} {b}" missing Tags b`},
+		{template: "{a}", tags: []Tag{T("a", 1), T("c", 3)}, expected: `errors: template "{a}" unused Tags c`},
+		{template: "{b}", tags: []Tag{T("c", 3)}, expected: `errors: template "{b}" missing Tags b; unused Tags c`},
+		{template: "{{a}}", tags: []Tag{T("a", 1)}, expected: `errors: template "{{a}}" unused Tags a`},
+	}
+
+	for i, tt := range tests {
+		err := CheckTemplate(tt.template, tt.tags...)
+		if (err == nil) != (tt.expected == "") || (err != nil && err.Error() != tt.expected) {
+			t.Errorf("#%d want %q got %v", i, tt.expected, err)
+		}
+	}
+}
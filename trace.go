@@ -0,0 +1,56 @@
+package errors
+
+import (
+	"context"
+	"runtime/trace"
+	"sync/atomic"
+
+	"github.com/go-playground/errors/v6/internal/names"
+)
+
+var tracing int32
+
+// RegisterTracing enables emitting a runtime/trace log event each time a Chain is created or wrapped, so errors
+// appear on the goroutine timeline of `go tool trace`. Events are only emitted while tracing is active and are
+// associated with the current task of the context when using NewCtx or WrapCtx.
+//
+// The events category is the Chain's types, separated by a comma, or "error" when it has none, and the message the
+// operation, source and error message eg. `wrap github.com/org/module/db.go:42:Load failed to load: EOF`.
+func RegisterTracing(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&tracing, v)
+}
+
+// traceLink emits the trace event for the Chain if enabled and tracing is active.
+func traceLink(ctx context.Context, c Chain, op string) {
+	if atomic.LoadInt32(&tracing) == 0 || !trace.IsEnabled() {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var types []string
+	for _, l := range c {
+		for _, typ := range l.Types {
+			if !names.Contains(types, typ) {
+				types = append(types, typ)
+			}
+		}
+	}
+	category := "error"
+	if len(types) > 0 {
+		category = string(AppendTypes(make([]byte, 0, 32), types))
+	}
+
+	b := make([]byte, 0, 128)
+	b = append(b, op...)
+	b = append(b, ' ')
+	b = AppendSource(b, c.current().Source, sourcePathPolicy)
+	b = append(b, ' ')
+	b = appendCompact(b, c)
+	trace.Log(ctx, category, string(b))
+}
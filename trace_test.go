@@ -0,0 +1,39 @@
+package errors
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"runtime/trace"
+	"testing"
+)
+
+func TestTracing(t *testing.T) {
+	var buf bytes.Buffer
+	if err := trace.Start(&buf); err != nil {
+		t.Skipf("tracing unavailable: %s", err)
+	}
+
+	_ = Wrap(io.EOF, "untraced").AddTypes("TraceDisabled")
+
+	RegisterTracing(true)
+	ctx, task := trace.NewTask(context.Background(), "request")
+	c := WrapCtx(ctx, New("trace root").AddTypes("TraceType"), "trace wrap")
+	task.End()
+	RegisterTracing(false)
+
+	trace.Stop()
+	if len(c) != 2 {
+		t.Fatalf("want wrapped Chain got %s", c)
+	}
+
+	out := buf.Bytes()
+	for _, s := range []string{"new ", "trace root", "wrap ", "trace wrap: trace root", "TraceType"} {
+		if !bytes.Contains(out, []byte(s)) {
+			t.Errorf("want %q in trace", s)
+		}
+	}
+	if bytes.Contains(out, []byte("untraced")) {
+		t.Error("want no events when disabled")
+	}
+}
@@ -0,0 +1,178 @@
+package errors
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+
+	runtimeext "github.com/go-playground/pkg/v5/runtime"
+)
+
+// std is the Wrapper used by the package level functions.
+var std = new(Wrapper)
+
+// Wrapper creates and wraps errors using its own configuration, allowing it to be scoped eg. to a single test.
+//
+// Chains created by a Wrapper remember it so that subsequent wrapping, including using Chain.Wrap and the package
+// level functions, uses the same configuration. The zero value uses the registered configuration.
+type Wrapper struct {
+
+	// SourceProvider provides the source of each Link, the registered SourceProvider is used when nil.
+	SourceProvider SourceProvider
+
+	// Capture sets the metadata captured for each Link, in addition to that set using RegisterCapture.
+	Capture Capture
+}
+
+// New creates an error with the provided text and automatically wraps it with line information.
+func (w *Wrapper) New(s string) Chain {
+	return w.wrap(stderrors.New(s), "", 3)
+}
+
+// Newf creates an error with the provided text and automatically wraps it with line information.
+// it also accepts a variadic for optional message formatting.
+func (w *Wrapper) Newf(format string, a ...any) Chain {
+	return w.wrap(fmt.Errorf(format, a...), "", 3)
+}
+
+// Wrap encapsulates the error, stores a contextual prefix and automatically obtains
+// a stack trace.
+func (w *Wrapper) Wrap(err error, prefix string) Chain {
+	return w.wrap(err, prefix, 3)
+}
+
+// Wrapf encapsulates the error, stores a contextual prefix and automatically obtains
+// a stack trace.
+// it also accepts a variadic for prefix formatting.
+func (w *Wrapper) Wrapf(err error, prefix string, a ...any) Chain {
+	return w.wrap(err, fmt.Sprintf(prefix, a...), 3)
+}
+
+// WrapSkipFrames is a special version of Wrap that skips extra n frames when determining error location.
+// Normally only used when wrapping the library
+func (w *Wrapper) WrapSkipFrames(err error, prefix string, n uint) Chain {
+	return w.wrap(err, prefix, int(n)+3)
+}
+
+// NewCtx is the same as New and captures the pprof labels of the context, see CaptureLabels.
+func (w *Wrapper) NewCtx(ctx context.Context, s string) Chain {
+	return w.wrapCtx(ctx, stderrors.New(s), "", 3)
+}
+
+// WrapCtx is the same as Wrap and captures the pprof labels of the context, see CaptureLabels.
+func (w *Wrapper) WrapCtx(ctx context.Context, err error, prefix string) Chain {
+	return w.wrapCtx(ctx, err, prefix, 3)
+}
+
+// WrapIf is the same as Wrap except it returns nil, rather than panicking, when err is nil, see WrapIf.
+func (w *Wrapper) WrapIf(err error, prefix string) error {
+	if err == nil {
+		return nil
+	}
+	return w.wrap(err, prefix, 3)
+}
+
+// Annotate wraps the error pointed to by err, when it is not nil, with the formatted prefix and Tag arguments, see
+// Annotate.
+func (w *Wrapper) Annotate(err *error, format string, a ...any) {
+	w.annotate(err, format, a, 4)
+}
+
+func (w *Wrapper) annotate(err *error, format string, a []any, skipFrames int) {
+	if *err == nil {
+		return
+	}
+	var tags []Tag
+	args := make([]any, 0, len(a))
+	for _, arg := range a {
+		if tag, ok := arg.(Tag); ok {
+			tags = append(tags, tag)
+		} else {
+			args = append(args, arg)
+		}
+	}
+	c := w.wrap(*err, fmt.Sprintf(format, args...), skipFrames)
+	if len(tags) > 0 {
+		c = c.AddTags(tags...)
+	}
+	*err = c
+}
+
+func (w *Wrapper) wrap(err error, prefix string, skipFrames int) Chain {
+	return w.wrapCtx(nil, err, prefix, skipFrames+1)
+}
+
+func (w *Wrapper) wrapCtx(ctx context.Context, err error, prefix string, skipFrames int) Chain {
+	if err == nil {
+		panic("errors: Wrap|Wrapf called with nil error")
+	}
+	return wrapLink(ctx, err, w.resolve(err).newLink(ctx, prefix, skipFrames))
+}
+
+// resolve returns the Wrapper to use for wrapping the error, the Wrapper of the Chain being wrapped is used when
+// wrapping using the package level functions.
+func (w *Wrapper) resolve(err error) *Wrapper {
+	if w == nil || w == std {
+		w = std
+		if c, ok := err.(Chain); ok && len(c) > 0 && c.current().extension().wrapper != nil {
+			w = c.current().extension().wrapper
+		}
+	}
+	return w
+}
+
+func (w *Wrapper) newLink(ctx context.Context, prefix string, skipFrames int) *Link {
+	source, origin := w.source(skipFrames)
+	l := &Link{Prefix: prefix, Source: source}
+	// the default Wrapper is used when there is none
+	if meta := w.newLinkMeta(ctx); origin != nil || meta != nil || w != std {
+		l.ext = &linkExt{origin: origin, meta: meta, wrapper: w}
+	}
+	return l
+}
+
+// source returns the frame skip frames above the caller of source, along with the frame it was skipped from to reach
+// the application frame when using the default SourceProvider and FrameFilters, see Link.Origin.
+func (w *Wrapper) source(skip int) (runtimeext.Frame, *runtimeext.Frame) {
+	p := sourceProvider.Load().(sourceProviderHolder).p
+	if w != nil && w.SourceProvider != nil {
+		p = w.SourceProvider
+	}
+	// fast path avoiding the additional frame of the interface call
+	if _, ok := p.(runtimeSource); ok {
+		return stackLevel(skip + 1)
+	}
+	return p.Source(skip + 1), nil
+}
+
+// wrapLink adds the supplied Link, containing the prefix and source, to the error Chain creating it if necessary.
+// The context, which may be nil, is used to associate trace events with the current task.
+func wrapLink(ctx context.Context, err error, l *Link) (c Chain) {
+	var ok bool
+	if c, ok = err.(Chain); ok {
+		if meta := l.extension().meta; meta != nil {
+			meta.root = c.rootTime(meta.root)
+		}
+		c = c.append(l)
+		notify(&wrapObservers, c, l)
+		traceLink(ctx, c, "wrap")
+		return
+	}
+	if l.Prefix == "" {
+		l.Err = err
+		c = Chain{l}
+		RunHelpers(c, err)
+	} else {
+		ext := l.ext
+		// the template only applies to the Link being added
+		if ext != nil && ext.template != "" {
+			ext = &linkExt{origin: ext.origin, meta: ext.meta, wrapper: ext.wrapper}
+		}
+		c = Chain{&Link{Err: err, Source: l.Source, ext: ext}}
+		RunHelpers(c, err)
+		c = append(c, l)
+	}
+	notify(&newObservers, c, l)
+	traceLink(ctx, c, "new")
+	return
+}